@@ -0,0 +1,62 @@
+package steamcmd
+
+import (
+	"github.com/pkg/errors"
+	"sync"
+)
+
+var (
+	// commandAliases maps each CommandType alias registered by RegisterCommandAlias to its canonical CommandType.
+	commandAliases = make(map[CommandType]CommandType)
+	// commandAliasesMu protects commandAliases.
+	commandAliasesMu sync.RWMutex
+)
+
+// RegisterCommandAlias registers the given alias CommandType for the given target CommandType. The alias can then be
+// used anywhere that the target can be used, such as SteamCMD.AddCommandType and NewCommandWithArgs. If the target is
+// itself an alias, then the alias will point to the canonical CommandType of the target. An alias cannot shadow a
+// built-in CommandType, and cannot be registered more than once.
+//
+//	const GetAppInfo steamcmd.CommandType = 100
+//	if err := steamcmd.RegisterCommandAlias(GetAppInfo, steamcmd.AppInfoPrint); err != nil {
+//		panic(err)
+//	}
+func RegisterCommandAlias(alias CommandType, target CommandType) error {
+	if _, ok := commands[alias]; ok {
+		return errors.Errorf("alias %d cannot shadow the built-in command type %q", alias, alias.String())
+	}
+
+	commandAliasesMu.Lock()
+	defer commandAliasesMu.Unlock()
+	if existing, ok := commandAliases[alias]; ok {
+		return errors.Errorf("alias %d is already registered for the command type %d", alias, existing)
+	}
+
+	if canonical, ok := commandAliases[target]; ok {
+		target = canonical
+	}
+
+	if _, ok := commands[target]; !ok {
+		return errors.Errorf("cannot find target command type %d in commands lookup", target)
+	}
+	commandAliases[alias] = target
+	return nil
+}
+
+// ResolveAlias returns the canonical CommandType for the given CommandType. If the given CommandType is not an alias
+// registered using RegisterCommandAlias, then it is returned as is.
+func ResolveAlias(ct CommandType) CommandType {
+	commandAliasesMu.RLock()
+	defer commandAliasesMu.RUnlock()
+	if canonical, ok := commandAliases[ct]; ok {
+		return canonical
+	}
+	return ct
+}
+
+// lookupCommand looks up the Command for the given CommandType within the default Command bindings, after resolving
+// any alias using ResolveAlias.
+func lookupCommand(ct CommandType) (Command, bool) {
+	command, ok := commands[ResolveAlias(ct)]
+	return command, ok
+}
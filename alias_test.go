@@ -0,0 +1,58 @@
+package steamcmd
+
+import "testing"
+
+func TestRegisterCommandAlias(t *testing.T) {
+	const (
+		getAppInfo  CommandType = 100
+		printInfo   CommandType = 101
+		unknownType CommandType = 102
+	)
+
+	if err := RegisterCommandAlias(getAppInfo, AppInfoPrint); err != nil {
+		t.Fatalf("could not register alias: %v", err)
+	}
+	if err := RegisterCommandAlias(printInfo, getAppInfo); err != nil {
+		t.Fatalf("could not register alias of an alias: %v", err)
+	}
+
+	for testNo, test := range []struct {
+		alias       CommandType
+		target      CommandType
+		expectedErr bool
+	}{
+		{Quit, AppInfoPrint, true},
+		{getAppInfo, Quit, true},
+		{unknownType, CommandType(200), true},
+	} {
+		if err := RegisterCommandAlias(test.alias, test.target); (err != nil) != test.expectedErr {
+			t.Errorf("%d: expected error to be %t, got %v", testNo+1, test.expectedErr, err)
+		}
+	}
+
+	for _, alias := range []CommandType{getAppInfo, printInfo} {
+		if canonical := ResolveAlias(alias); canonical != AppInfoPrint {
+			t.Errorf("expected alias %d to resolve to AppInfoPrint, got %d", alias, canonical)
+		}
+
+		if command := NewCommandWithArgs(alias, 477160); command.Command.Type != AppInfoPrint {
+			t.Errorf("expected NewCommandWithArgs to resolve alias %d to AppInfoPrint, got %s", alias, command.Command.Type)
+		}
+
+		if alias.String() != AppInfoPrint.String() {
+			t.Errorf("expected alias %d to have the string %q, got %q", alias, AppInfoPrint.String(), alias.String())
+		}
+	}
+
+	if ct, err := CommandTypeFromString("101"); err != nil || ct != AppInfoPrint {
+		t.Errorf("expected CommandTypeFromString to resolve alias to AppInfoPrint, got %d (err: %v)", ct, err)
+	}
+
+	sc := New(false)
+	if err := sc.AddCommandType(getAppInfo, 477160); err != nil {
+		t.Fatalf("could not add command type alias: %v", err)
+	}
+	if sc.serialisedCommands[1] != "+app_info_print 477160" {
+		t.Errorf("expected serialised command %q, got %q", "+app_info_print 477160", sc.serialisedCommands[1])
+	}
+}
@@ -0,0 +1,145 @@
+package steamcmd
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"strings"
+	"time"
+)
+
+// AppInfo is a structured representation of the most useful fields within the parsed output of the AppInfoPrint
+// command. It can be constructed using ParseAppInfo.
+type AppInfo struct {
+	// AppID is the ID of the app.
+	AppID int64
+	// Name is the name of the app.
+	Name string
+	// Type is the type of the app. I.e. "Game", "DLC", "Tool".
+	Type string
+	// ReleaseDate is the date that the app was released on Steam. This will be the zero time.Time if it could not be
+	// found.
+	ReleaseDate time.Time
+	// OriginalReleaseDate is the date that the app was first released, which might have been on another platform. This
+	// will be the zero time.Time if it could not be found.
+	OriginalReleaseDate time.Time
+	// Warnings contains any non-fatal issues that occurred whilst parsing the AppInfo.
+	Warnings []string
+}
+
+// ParseAppInfo will parse the given output from the AppInfoPrint command into an AppInfo. An error will only be
+// returned if the output does not contain a "common" section. Any fields that cannot be found will be left as their
+// zero value.
+//
+// The ReleaseDate is parsed using AppSteamReleaseDate, and the OriginalReleaseDate is parsed using
+// AppOriginalReleaseDate. If neither of these can be parsed then a warning will be added to AppInfo.Warnings.
+func ParseAppInfo(output map[string]any) (info *AppInfo, err error) {
+	if _, err = GetNestedValue(output, "common"); err != nil {
+		return nil, errors.Wrap(err, "output is not from AppInfoPrint")
+	}
+
+	info = &AppInfo{Warnings: make([]string, 0)}
+	info.AppID, _ = AppID(output)
+	info.Name, _ = AppName(output)
+	info.Type, _ = AppType(output)
+
+	var steamErr, oErr error
+	info.ReleaseDate, steamErr = AppSteamReleaseDate(output)
+	info.OriginalReleaseDate, oErr = AppOriginalReleaseDate(output)
+	if steamErr != nil && oErr != nil {
+		info.Warnings = append(info.Warnings, fmt.Sprintf(
+			"could not parse release date from steam_release_date (%v) or original_release_date (%v)",
+			steamErr, oErr,
+		))
+	}
+	return
+}
+
+// ValidationError is an issue with a field of an AppInfo that is found by ValidateAppInfo or StrictValidateAppInfo.
+type ValidationError struct {
+	// Field is the name of the AppInfo field that has the issue.
+	Field string
+	// Issue describes what is wrong with the field.
+	Issue string
+}
+
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Issue)
+}
+
+// knownAppTypes are the lowercase types of app that can be found in the "type" field of the output of AppInfoPrint.
+var knownAppTypes = map[string]struct{}{
+	"game":        {},
+	"dlc":         {},
+	"demo":        {},
+	"application": {},
+	"tool":        {},
+	"music":       {},
+	"video":       {},
+	"series":      {},
+	"episode":     {},
+	"beta":        {},
+	"config":      {},
+	"hardware":    {},
+	"advertising": {},
+}
+
+// ValidateAppInfo checks that the required fields of the given AppInfo are populated. These are:
+//   - Name is not empty.
+//   - AppID is greater than 0.
+//   - Type is a known type of app (case-insensitive). I.e. "Game", "DLC", "Tool".
+//   - ReleaseDate is not the zero time.Time if Type is "Game" or "DLC".
+//
+// A ValidationError is returned for each field that has an issue. If the AppInfo is valid then no ValidationError will
+// be returned.
+func ValidateAppInfo(info *AppInfo) []ValidationError {
+	validationErrors := make([]ValidationError, 0)
+	if info == nil {
+		return append(validationErrors, ValidationError{Field: "AppInfo", Issue: "is nil"})
+	}
+
+	if info.Name == "" {
+		validationErrors = append(validationErrors, ValidationError{Field: "Name", Issue: "is empty"})
+	}
+
+	if info.AppID <= 0 {
+		validationErrors = append(validationErrors, ValidationError{
+			Field: "AppID",
+			Issue: fmt.Sprintf("must be greater than 0, got %d", info.AppID),
+		})
+	}
+
+	appType := strings.ToLower(info.Type)
+	if _, ok := knownAppTypes[appType]; !ok {
+		validationErrors = append(validationErrors, ValidationError{
+			Field: "Type",
+			Issue: fmt.Sprintf("%q is not a known type of app", info.Type),
+		})
+	}
+
+	if (appType == "game" || appType == "dlc") && info.ReleaseDate.IsZero() {
+		validationErrors = append(validationErrors, ValidationError{
+			Field: "ReleaseDate",
+			Issue: fmt.Sprintf("is not set for an app of type %q", info.Type),
+		})
+	}
+	return validationErrors
+}
+
+// StrictValidateAppInfo is the same as ValidateAppInfo, but a ValidationError is also returned for each optional field
+// of the given AppInfo that is not populated. I.e. the ReleaseDate of an app of any type, and the OriginalReleaseDate.
+func StrictValidateAppInfo(info *AppInfo) []ValidationError {
+	validationErrors := ValidateAppInfo(info)
+	if info == nil {
+		return validationErrors
+	}
+
+	appType := strings.ToLower(info.Type)
+	if appType != "game" && appType != "dlc" && info.ReleaseDate.IsZero() {
+		validationErrors = append(validationErrors, ValidationError{Field: "ReleaseDate", Issue: "is not set"})
+	}
+
+	if info.OriginalReleaseDate.IsZero() {
+		validationErrors = append(validationErrors, ValidationError{Field: "OriginalReleaseDate", Issue: "is not set"})
+	}
+	return validationErrors
+}
@@ -0,0 +1,187 @@
+package steamcmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseAppInfo(t *testing.T) {
+	for testNo, test := range []struct {
+		output      map[string]any
+		expected    AppInfo
+		warnings    int
+		expectedErr bool
+	}{
+		{
+			output: map[string]any{
+				"appid": "477160",
+				"common": map[string]any{
+					"name":                  "Human: Fall Flat",
+					"type":                  "Game",
+					"steam_release_date":    "1469718000",
+					"original_release_date": "8 Oct, 2019",
+				},
+			},
+			expected: AppInfo{
+				AppID:               477160,
+				Name:                "Human: Fall Flat",
+				Type:                "Game",
+				ReleaseDate:         time.Unix(1469718000, 0).UTC(),
+				OriginalReleaseDate: time.Date(2019, 10, 8, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			output: map[string]any{
+				"appid": "477160",
+				"common": map[string]any{
+					"name":                  "Human: Fall Flat",
+					"type":                  "Game",
+					"original_release_date": "8 Oct, 2019",
+				},
+			},
+			expected: AppInfo{
+				AppID:               477160,
+				Name:                "Human: Fall Flat",
+				Type:                "Game",
+				OriginalReleaseDate: time.Date(2019, 10, 8, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			output: map[string]any{
+				"appid": "477160",
+				"common": map[string]any{
+					"name":               "Human: Fall Flat",
+					"type":               "Game",
+					"steam_release_date": "1469718000",
+				},
+			},
+			expected: AppInfo{
+				AppID:       477160,
+				Name:        "Human: Fall Flat",
+				Type:        "Game",
+				ReleaseDate: time.Unix(1469718000, 0).UTC(),
+			},
+		},
+		{
+			output: map[string]any{
+				"appid": "477160",
+				"common": map[string]any{
+					"name":               "Human: Fall Flat",
+					"type":               "Game",
+					"steam_release_date": "Coming Soon",
+				},
+			},
+			expected: AppInfo{
+				AppID: 477160,
+				Name:  "Human: Fall Flat",
+				Type:  "Game",
+			},
+			warnings: 1,
+		},
+		{
+			output:      map[string]any{"appid": "477160"},
+			expectedErr: true,
+		},
+	} {
+		info, err := ParseAppInfo(test.output)
+		if test.expectedErr {
+			if err == nil {
+				t.Errorf("%d: expected an error, got nil", testNo+1)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", testNo+1, err)
+			continue
+		}
+
+		if info.AppID != test.expected.AppID || info.Name != test.expected.Name || info.Type != test.expected.Type {
+			t.Errorf("%d: expected %+v, got %+v", testNo+1, test.expected, *info)
+		}
+
+		if !info.ReleaseDate.Equal(test.expected.ReleaseDate) {
+			t.Errorf("%d: expected release date %v, got %v", testNo+1, test.expected.ReleaseDate, info.ReleaseDate)
+		}
+
+		if !info.OriginalReleaseDate.Equal(test.expected.OriginalReleaseDate) {
+			t.Errorf(
+				"%d: expected original release date %v, got %v",
+				testNo+1, test.expected.OriginalReleaseDate, info.OriginalReleaseDate,
+			)
+		}
+
+		if len(info.Warnings) != test.warnings {
+			t.Errorf("%d: expected %d warnings, got %d (%v)", testNo+1, test.warnings, len(info.Warnings), info.Warnings)
+		}
+	}
+}
+
+func TestValidateAppInfo(t *testing.T) {
+	releaseDate := time.Unix(1469718000, 0).UTC()
+	for testNo, test := range []struct {
+		info           *AppInfo
+		expectedFields []string
+		strictFields   []string
+	}{
+		{
+			&AppInfo{
+				AppID:               477160,
+				Name:                "Human: Fall Flat",
+				Type:                "Game",
+				ReleaseDate:         releaseDate,
+				OriginalReleaseDate: releaseDate,
+			},
+			[]string{},
+			[]string{},
+		},
+		{
+			&AppInfo{AppID: 477160, Name: "Human: Fall Flat", Type: "Game", ReleaseDate: releaseDate},
+			[]string{},
+			[]string{"OriginalReleaseDate"},
+		},
+		{
+			&AppInfo{AppID: 477160, Name: "Human: Fall Flat", Type: "dlc"},
+			[]string{"ReleaseDate"},
+			[]string{"ReleaseDate", "OriginalReleaseDate"},
+		},
+		{
+			&AppInfo{AppID: 228980, Name: "Steamworks Common Redistributables", Type: "Tool"},
+			[]string{},
+			[]string{"ReleaseDate", "OriginalReleaseDate"},
+		},
+		{
+			&AppInfo{Type: "Unknown"},
+			[]string{"Name", "AppID", "Type"},
+			[]string{"Name", "AppID", "Type", "ReleaseDate", "OriginalReleaseDate"},
+		},
+		{
+			nil,
+			[]string{"AppInfo"},
+			[]string{"AppInfo"},
+		},
+	} {
+		for _, validator := range []struct {
+			name     string
+			validate func(*AppInfo) []ValidationError
+			expected []string
+		}{
+			{"ValidateAppInfo", ValidateAppInfo, test.expectedFields},
+			{"StrictValidateAppInfo", StrictValidateAppInfo, test.strictFields},
+		} {
+			validationErrors := validator.validate(test.info)
+			fields := make([]string, len(validationErrors))
+			for i, validationError := range validationErrors {
+				fields[i] = validationError.Field
+			}
+
+			if !reflect.DeepEqual(fields, validator.expected) {
+				t.Errorf(
+					"%d: expected %s to return errors for %v, got %v",
+					testNo+1, validator.name, validator.expected, validationErrors,
+				)
+			}
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package steamcmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"github.com/pkg/errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// auditLogColumns are the columns of the CSV written by SteamCMD.ExportAuditLog.
+var auditLogColumns = []string{
+	"timestamp",
+	"command_type",
+	"args",
+	"duration_ms",
+	"retries",
+	"success",
+	"error_message",
+}
+
+// ExportAuditLog writes a CSV to the given io.Writer that contains a row for each ExecutionTrace in
+// SteamCMD.CommandHistory. The first row contains the names of the columns, which are:
+//
+//	timestamp: the ExecutionTrace.StartTime in RFC 3339 format.
+//	command_type: the SteamCMD representation of the CommandType. I.e. "app_info_print".
+//	args: the args that the Command was executed with, separated by spaces. The values of any Secret Arg are redacted.
+//	duration_ms: the ExecutionTrace.Duration in milliseconds.
+//	retries: the ExecutionTrace.Retries.
+//	success: whether the Command was executed without an error.
+//	error_message: the error that occurred whilst executing the Command, if any.
+//
+// The args and error_message columns will also be redacted using the patterns given to WithRedactSecrets.
+func (sc *SteamCMD) ExportAuditLog(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(auditLogColumns); err != nil {
+		return errors.Wrap(err, "could not write audit log header")
+	}
+
+	for i, trace := range sc.CommandHistory() {
+		traceArgs := trace.Args
+		if command, ok := lookupCommand(trace.CommandType); ok {
+			traceArgs = command.redactArgs(traceArgs)
+		}
+
+		args := make([]string, len(traceArgs))
+		for j, arg := range traceArgs {
+			args[j] = fmt.Sprintf("%v", arg)
+		}
+
+		errorMessage := ""
+		if trace.Err != nil {
+			errorMessage = sc.redactSecrets(trace.Err.Error())
+		}
+
+		if err := writer.Write([]string{
+			trace.StartTime.Format(time.RFC3339Nano),
+			trace.CommandType.String(),
+			sc.redactSecrets(strings.Join(args, " ")),
+			strconv.FormatInt(trace.Duration.Milliseconds(), 10),
+			strconv.Itoa(trace.Retries),
+			strconv.FormatBool(trace.Err == nil),
+			errorMessage,
+		}); err != nil {
+			return errors.Wrapf(err, "could not write audit log row for command no. %d", i+1)
+		}
+	}
+
+	writer.Flush()
+	return errors.Wrap(writer.Error(), "could not flush audit log")
+}
+
+// ExportAuditLogFile writes the CSV from SteamCMD.ExportAuditLog to the file at the given path. The file is written
+// atomically, so the file at the given path will never contain a partially written audit log.
+func (sc *SteamCMD) ExportAuditLogFile(path string) error {
+	var buf bytes.Buffer
+	if err := sc.ExportAuditLog(&buf); err != nil {
+		return err
+	}
+	return writeFileAtomic(path, buf.Bytes(), 0o644)
+}
@@ -0,0 +1,54 @@
+package steamcmd
+
+import (
+	"encoding/csv"
+	"github.com/pkg/errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSteamCMD_ExportAuditLog(t *testing.T) {
+	startTime := time.Date(2022, 10, 7, 14, 32, 33, 0, time.UTC)
+	sc := New(true, WithRedactSecrets())
+	sc.traces = []ExecutionTrace{
+		{CommandType: AppInfoPrint, Args: []any{477160}, Retries: 1},
+		{CommandType: AppInfoPrint, Args: []any{620}, Err: errors.New("could not parse output, \"unexpected EOF\"")},
+		{CommandType: Login, Args: []any{"bob", "hunter2", "4KXJ2"}, Err: errors.New("could not login bob hunter2")},
+		{CommandType: Quit},
+		// This ExecutionTrace has not been executed yet, so it should not be exported
+		{CommandType: AppInfoPrint, Args: []any{730}},
+	}
+	for i := range sc.traces[:4] {
+		sc.traces[i].setTimes(startTime, startTime.Add(time.Duration(i+1)*1500*time.Millisecond))
+	}
+
+	path := filepath.Join(t.TempDir(), "audit.csv")
+	if err := sc.ExportAuditLogFile(path); err != nil {
+		t.Fatalf("could not export audit log: %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("could not open audit log: %v", err)
+	}
+	defer f.Close()
+
+	var records [][]string
+	if records, err = csv.NewReader(f).ReadAll(); err != nil {
+		t.Fatalf("could not read audit log as CSV: %v", err)
+	}
+
+	expected := [][]string{
+		auditLogColumns,
+		{"2022-10-07T14:32:33Z", "app_info_print", "477160", "1500", "1", "true", ""},
+		{"2022-10-07T14:32:33Z", "app_info_print", "620", "3000", "0", "false", "could not parse output, \"unexpected EOF\""},
+		{"2022-10-07T14:32:33Z", "login", "bob [REDACTED] [REDACTED]", "4500", "0", "false", "could not login bob [REDACTED]"},
+		{"2022-10-07T14:32:33Z", "quit", "", "6000", "0", "true", ""},
+	}
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("expected audit log %v, got %v", expected, records)
+	}
+}
@@ -0,0 +1,72 @@
+package steamcmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+)
+
+const (
+	appInfoPrintFixturePath = "testdata/app_info_print_477160.txt"
+	// largeAppInfoPrintFixtureSize is the minimum size of the synthetic fixture used by
+	// BenchmarkAppInfoPrintParserLarge.
+	largeAppInfoPrintFixtureSize = 500 * 1024
+)
+
+// appInfoPrintFixture reads the AppInfoPrint fixture at appInfoPrintFixturePath.
+func appInfoPrintFixture(b *testing.B) []byte {
+	b.Helper()
+	input, err := os.ReadFile(appInfoPrintFixturePath)
+	if err != nil {
+		b.Fatalf("could not read fixture %s: %v", appInfoPrintFixturePath, err)
+	}
+	return input
+}
+
+// largeAppInfoPrintFixture creates a synthetic AppInfoPrint output of at least largeAppInfoPrintFixtureSize bytes by
+// adding depots to the fixture at appInfoPrintFixturePath.
+func largeAppInfoPrintFixture(b *testing.B) []byte {
+	b.Helper()
+	input := appInfoPrintFixture(b)
+	end := bytes.LastIndexByte(input, '}')
+
+	var depots bytes.Buffer
+	for depotID := 1000000; len(input)+depots.Len() < largeAppInfoPrintFixtureSize; depotID++ {
+		fmt.Fprintf(
+			&depots,
+			"\t\"depot_%d\"\n\t{\n\t\t\"config\"\n\t\t{\n\t\t\t\"oslist\"\t\t\"windows\"\n\t\t}\n"+
+				"\t\t\"manifests\"\n\t\t{\n\t\t\t\"public\"\t\t\"%d\"\n\t\t}\n\t}\n",
+			depotID, depotID*7919,
+		)
+	}
+
+	large := make([]byte, 0, len(input)+depots.Len())
+	large = append(large, input[:end]...)
+	large = append(large, depots.Bytes()...)
+	return append(large, input[end:]...)
+}
+
+func benchmarkAppInfoPrintParser(input []byte, b *testing.B) {
+	parser := commands[AppInfoPrint].Parser
+	if _, err := parser(input); err != nil {
+		b.Fatalf("could not parse fixture: %v", err)
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := parser(input); err != nil {
+			b.Fatalf("could not parse fixture: %v", err)
+		}
+	}
+}
+
+func BenchmarkAppInfoPrintParser(b *testing.B) {
+	benchmarkAppInfoPrintParser(appInfoPrintFixture(b), b)
+}
+
+func BenchmarkAppInfoPrintParserLarge(b *testing.B) {
+	benchmarkAppInfoPrintParser(largeAppInfoPrintFixture(b), b)
+}
@@ -0,0 +1,102 @@
+package steamcmd
+
+import (
+	"github.com/andygello555/agem"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// BulkAppInfoPrint will execute the AppInfoPrint command for each of the given app IDs using a single interactive
+// SteamCMD that is created using the given Option(s). The parsed output of each app is returned, keyed by its app ID.
+//
+// An error for one app will not stop the other apps from being printed. Apps that could not be printed or parsed are
+// not included in the returned map, and their errors are merged into the returned error.
+func BulkAppInfoPrint(appIDs []int64, opts ...Option) (map[int64]map[string]any, error) {
+	return bulkAppInfoPrint(New(true, opts...), appIDs)
+}
+
+// bulkAppInfoPrint is the implementation for BulkAppInfoPrint that uses the given SteamCMD.
+func bulkAppInfoPrint(sc *SteamCMD, appIDs []int64) (outputs map[int64]map[string]any, err error) {
+	if err = sc.Start(); err != nil {
+		return nil, errors.Wrap(err, "could not start SteamCMD for bulk app info print")
+	}
+
+	outputs = make(map[int64]map[string]any)
+	errs := make([]error, 0)
+	for _, appID := range appIDs {
+		if err = sc.AddCommandType(AppInfoPrint, appID); err != nil {
+			errs = append(errs, errors.Wrapf(err, "could not print app info for %d", appID))
+			continue
+		}
+
+		parsedOutput := sc.ParsedOutputs[len(sc.ParsedOutputs)-1]
+		output, ok := parsedOutput.(map[string]any)
+		if !ok {
+			errs = append(errs, errors.Errorf(
+				"parsed output of AppInfoPrint for %d is not a map, it is a %T",
+				appID, parsedOutput,
+			))
+			continue
+		}
+		outputs[appID] = output
+	}
+
+	errs = append(errs, errors.Wrap(sc.Close(), "could not close SteamCMD for bulk app info print"))
+	return outputs, agem.MergeErrors(errs...)
+}
+
+// BulkAppInfoPrintResult is the result of BulkAppInfoPrintFiltered.
+type BulkAppInfoPrintResult struct {
+	// Outputs are the parsed outputs of the apps that matched the filter, keyed by their app ID.
+	Outputs map[int64]map[string]any
+	// FilteredOut contains the app IDs of the apps that did not match the filter.
+	FilteredOut map[int64]struct{}
+}
+
+// BulkAppInfoPrintFiltered calls BulkAppInfoPrint with the given app IDs and Option(s), then applies the given filter
+// to the parsed output of each app. Apps that do not match the filter are excluded from BulkAppInfoPrintResult.Outputs,
+// and are instead added to BulkAppInfoPrintResult.FilteredOut. FilterGamesOnly and FilterDLCOnly can be used as
+// filters.
+//
+// Like BulkAppInfoPrint, the result will contain the apps that succeeded even if an error is returned.
+func BulkAppInfoPrintFiltered(
+	appIDs []int64,
+	filter func(output map[string]any) bool,
+	opts ...Option,
+) (*BulkAppInfoPrintResult, error) {
+	outputs, err := BulkAppInfoPrint(appIDs, opts...)
+	return filterBulkOutputs(outputs, filter), err
+}
+
+// filterBulkOutputs applies the given filter to the given outputs from BulkAppInfoPrint.
+func filterBulkOutputs(outputs map[int64]map[string]any, filter func(output map[string]any) bool) *BulkAppInfoPrintResult {
+	result := &BulkAppInfoPrintResult{
+		Outputs:     make(map[int64]map[string]any),
+		FilteredOut: make(map[int64]struct{}),
+	}
+
+	for appID, output := range outputs {
+		if filter(output) {
+			result.Outputs[appID] = output
+		} else {
+			result.FilteredOut[appID] = struct{}{}
+		}
+	}
+	return result
+}
+
+// appTypeFilter returns a filter for BulkAppInfoPrintFiltered that will only match apps with the given AppType. Types
+// are compared case-insensitively.
+func appTypeFilter(appType string) func(output map[string]any) bool {
+	return func(output map[string]any) bool {
+		t, err := AppType(output)
+		return err == nil && strings.EqualFold(t, appType)
+	}
+}
+
+var (
+	// FilterGamesOnly is a filter for BulkAppInfoPrintFiltered that only matches apps with the "Game" AppType.
+	FilterGamesOnly = appTypeFilter("Game")
+	// FilterDLCOnly is a filter for BulkAppInfoPrintFiltered that only matches apps with the "DLC" AppType.
+	FilterDLCOnly = appTypeFilter("DLC")
+)
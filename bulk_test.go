@@ -0,0 +1,56 @@
+package steamcmd
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestBulkAppInfoPrintFiltered(t *testing.T) {
+	dlcOutput := strings.NewReplacer("477160", "1138660", "Human: Fall Flat", "Human: Fall Flat - Soundtrack", `"Game"`, `"DLC"`).
+		Replace(sampleAppInfoPrintOutput)
+	rs := &RecordedSession{Commands: []RecordedCommand{
+		{CommandType: AppInfoPrint, Args: []any{477160}, Output: []byte(sampleAppInfoPrintOutput)},
+		{CommandType: AppInfoPrint, Args: []any{1138660}, Output: []byte(dlcOutput)},
+	}}
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := rs.Save(path); err != nil {
+		t.Fatalf("could not save recorded session: %v", err)
+	}
+
+	sc, err := NewReplaySession(path)
+	if err != nil {
+		t.Fatalf("could not create replay session: %v", err)
+	}
+
+	var outputs map[int64]map[string]any
+	if outputs, err = bulkAppInfoPrint(sc, []int64{477160, 1138660}); err != nil {
+		t.Fatalf("could not bulk print app info: %v", err)
+	}
+
+	for testNo, test := range []struct {
+		filter              func(output map[string]any) bool
+		expectedOutputs     []int64
+		expectedFilteredOut map[int64]struct{}
+	}{
+		{FilterGamesOnly, []int64{477160}, map[int64]struct{}{1138660: {}}},
+		{FilterDLCOnly, []int64{1138660}, map[int64]struct{}{477160: {}}},
+	} {
+		result := filterBulkOutputs(outputs, test.filter)
+		if len(result.Outputs) != len(test.expectedOutputs) {
+			t.Errorf("%d: expected %d outputs, got %d", testNo+1, len(test.expectedOutputs), len(result.Outputs))
+		}
+
+		for _, appID := range test.expectedOutputs {
+			if _, ok := result.Outputs[appID]; !ok {
+				t.Errorf("%d: expected output for %d", testNo+1, appID)
+			}
+		}
+
+		if !reflect.DeepEqual(result.FilteredOut, test.expectedFilteredOut) {
+			t.Errorf("%d: expected filtered out %v, got %v", testNo+1, test.expectedFilteredOut, result.FilteredOut)
+		}
+	}
+}
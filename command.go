@@ -9,6 +9,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // ArgType is the type of an Arg. It represents how an Arg should be serialised and parsed.
@@ -19,6 +20,8 @@ const (
 	Number ArgType = iota
 	// String represents string values.
 	String
+	// Enum represents string values that must be one of the Arg.AllowedValues. Values are matched case-insensitively.
+	Enum ArgType = 3
 )
 
 // String returns the string representation of the ArgType.
@@ -28,6 +31,8 @@ func (at ArgType) String() string {
 		return "Number"
 	case String:
 		return "String"
+	case Enum:
+		return "Enum"
 	default:
 		return "<nil>"
 	}
@@ -74,14 +79,15 @@ func (at ArgType) DefaultSerialiser(value any) string {
 				at.String(), value, reflect.TypeOf(value).String()),
 			)
 		}
-	case String:
+	case String, Enum:
 		return value.(string)
 	default:
 		return "<nil>"
 	}
 }
 
-// DefaultValidator checks if the given value fits the ArgType.
+// DefaultValidator checks if the given value fits the ArgType. For Enum, this will only check whether the value is a
+// string, Arg.Validate will check whether the value is one of the Arg.AllowedValues.
 func (at ArgType) DefaultValidator(value any) bool {
 	switch at {
 	case Number:
@@ -91,7 +97,7 @@ func (at ArgType) DefaultValidator(value any) bool {
 		default:
 			return false
 		}
-	case String:
+	case String, Enum:
 		_, ok := value.(string)
 		return ok
 	default:
@@ -108,20 +114,97 @@ type Arg struct {
 	Required   bool
 	Validator  ArgValidator
 	Serialiser ArgSerialiser
+	// AllowedValues are the values that an Arg with the Enum ArgType can take.
+	AllowedValues []string
+	// Variadic indicates that the Arg can be given any number of values. This is only valid on the last Arg of a
+	// Command, and is ignored otherwise. A Required Variadic Arg must be given at least one value.
+	Variadic bool
+	// Flag is the flag that the Arg is serialised with when it has no Serialiser. I.e. "-beta". String Arg are
+	// serialised as the Flag followed by the value, and Number Arg are serialised as the bare Flag when the value is
+	// non-zero. Empty strings and zero values are serialised to an empty string, so that they are skipped by
+	// Command.Serialise.
+	Flag string
+	// Secret indicates that the value of the Arg is sensitive (i.e. a password). The values of Secret Arg are replaced
+	// with Redacted by Command.SerialiseRedacted, and in each ExecutionTrace and RecordedSession.
+	Secret bool
+}
+
+// NewEnumArg creates a new Arg with the Enum ArgType that can take any of the given values.
+func NewEnumArg(name string, values ...string) *Arg {
+	return &Arg{
+		Name:          name,
+		Type:          Enum,
+		AllowedValues: values,
+	}
+}
+
+// allowedValue returns the value within AllowedValues that case-insensitively matches the given value.
+func (a *Arg) allowedValue(value any) (string, bool) {
+	if s, ok := value.(string); ok {
+		for _, allowedValue := range a.AllowedValues {
+			if strings.EqualFold(s, allowedValue) {
+				return allowedValue, true
+			}
+		}
+	}
+	return "", false
 }
 
 // Serialise the given value to a string using the Serialiser for the Arg. If there is no Serialiser for the Arg then
-// the ArgType.DefaultSerialiser will be used instead.
+// the ArgType.DefaultSerialiser will be used instead. Args with the Enum ArgType will be serialised to the matching
+// value within AllowedValues when using the ArgType.DefaultSerialiser. Args with a Flag are serialised as described by
+// Arg.Flag.
 func (a *Arg) Serialise(value any) string {
 	if a.Serialiser != nil {
 		return a.Serialiser(value)
 	}
+
+	if a.Flag != "" {
+		return a.serialiseFlag(value)
+	}
+
+	if a.Type == Enum {
+		if allowedValue, ok := a.allowedValue(value); ok {
+			return allowedValue
+		}
+	}
 	return a.Type.DefaultSerialiser(value)
 }
 
-// Validate the given value against the Type of the Arg and the Validator for the Arg (if there is one).
+// serialiseFlag serialises the given value for an Arg with a Flag.
+func (a *Arg) serialiseFlag(value any) string {
+	serialised := a.Type.DefaultSerialiser(value)
+	if a.Type == Number {
+		if number, err := strconv.ParseFloat(serialised, 64); err == nil && number != 0 {
+			return a.Flag
+		}
+		return ""
+	}
+
+	if serialised == "" {
+		return ""
+	}
+	return a.Flag + " " + serialised
+}
+
+// zeroValue returns the value that an Arg with a Flag is given when it is skipped by DeserialiseCommandLine.
+func (a *Arg) zeroValue() any {
+	if a.Type == Number {
+		return int64(0)
+	}
+	return ""
+}
+
+// Validate the given value against the Type of the Arg and the Validator for the Arg (if there is one). Args with the
+// Enum ArgType will also check whether the value is one of the AllowedValues.
 func (a *Arg) Validate(value any) bool {
 	if a.Type.DefaultValidator(value) {
+		if a.Type == Enum {
+			if _, ok := a.allowedValue(value); !ok {
+				return false
+			}
+		}
+
 		if a.Validator != nil {
 			return a.Validator(value)
 		}
@@ -130,6 +213,35 @@ func (a *Arg) Validate(value any) bool {
 	return false
 }
 
+// RetiredAppError is implemented by errors that are returned when the app that is being looked up has been retired.
+type RetiredAppError interface {
+	error
+	// RetiredAppID returns the ID of the retired app.
+	RetiredAppID() int64
+}
+
+// ErrAppRetired is returned by the parser for the AppInfoPrint command when the app has been retired or removed by its
+// publisher. The output for these apps contains an "app_retired_publisher_request" section instead of a "common"
+// section. ErrAppRetired implements RetiredAppError.
+type ErrAppRetired struct {
+	AppID int64
+}
+
+func (e ErrAppRetired) Error() string {
+	return fmt.Sprintf("app %d has been retired by its publisher", e.AppID)
+}
+
+// RetiredAppID returns the AppID of the retired app.
+func (e ErrAppRetired) RetiredAppID() int64 {
+	return e.AppID
+}
+
+// AppIsRetired checks whether the given error (or any error that it wraps) is a RetiredAppError, such as ErrAppRetired.
+func AppIsRetired(err error) bool {
+	var retiredErr RetiredAppError
+	return errors.As(err, &retiredErr)
+}
+
 // CommandType represents a (sub)command that can be executed by SteamCMD.
 type CommandType int
 
@@ -138,33 +250,112 @@ const (
 	AppInfoPrint CommandType = iota
 	// Quit calls the "quit" command. It takes no arguments.
 	Quit
+	// AppInfoRequest calls the "app_info_request" command. It takes a sole Number as an Arg. This requests that the app
+	// info for the given app ID is fetched from Steam, and should be followed by AppInfoPrint to read the freshly fetched
+	// app info. See SteamCMD.FlowWithRequest.
+	AppInfoRequest
+	// AppInfoUpdate calls the "app_info_update" command. It takes an optional Number as an Arg, which forces the update
+	// when it is 1. This updates the local app info cache from Steam, so that subsequent AppInfoPrint commands do not
+	// return stale app info. See WithFreshData.
+	AppInfoUpdate
+	// AppUpdate calls the "app_update" command, which installs or updates an app. It takes a required Number app ID,
+	// followed by an optional String beta branch, an optional String beta password, and an optional Number which adds
+	// the "-validate" flag when it is non-zero. These optional Arg are serialised using their Arg.Flag, so empty beta
+	// branches and passwords are not serialised, and the validate flag can be given without them. I.e.
+	// NewCommandWithArgs(AppUpdate, 740, "", "", 1) is serialised to "+app_update 740 -validate".
+	AppUpdate
+	// ForceInstallDir calls the "force_install_dir" command. It takes a sole String as an Arg, which is the directory
+	// that apps will be installed to by AppUpdate. It must be queued/executed before any AppUpdate command. See
+	// SetInstallDir.
+	ForceInstallDir
+	// Login calls the "login" command. It takes a required String username, a required String password, and an
+	// optional String Steam Guard code. The parser returns ErrSteamGuardRequired if steamcmd asks for a Steam Guard code.
+	// The password and Steam Guard code are secret Args, so they are Redacted within the ExecutionTrace for the Command,
+	// any RecordedSession, SteamCMD.ExportAuditLog, and SaveParsedOutputs. NewWithCredentials can be used instead to log
+	// in when the SteamCMD starts.
+	Login
+	// AppUninstall calls the "app_uninstall" command, which removes an installed app. It takes a required Number app ID,
+	// and an optional String which adds the "-complete" flag when it is "-complete" (or "complete"). As uninstalling is
+	// destructive, it can only be queued/executed by a SteamCMD that has logged in with an account. See
+	// ErrUninstallRequiresLogin.
+	AppUninstall
+	// AppStatus calls the "app_status" command. It takes a sole Number as an Arg. The parsed output of this command is an
+	// *AppStatusResult.
+	AppStatus
 )
 
 // String returns the SteamCMD representation of the CommandType that will be used to call the command in the
-// steamcmd binary.
+// steamcmd binary. Aliases registered using RegisterCommandAlias will return the representation of their canonical
+// CommandType.
 func (ct CommandType) String() string {
-	switch ct {
+	switch ResolveAlias(ct) {
 	case AppInfoPrint:
 		return "app_info_print"
 	case Quit:
 		return "quit"
+	case AppInfoRequest:
+		return "app_info_request"
+	case AppInfoUpdate:
+		return "app_info_update"
+	case AppUpdate:
+		return "app_update"
+	case ForceInstallDir:
+		return "force_install_dir"
+	case Login:
+		return "login"
+	case AppUninstall:
+		return "app_uninstall"
+	case AppStatus:
+		return "app_status"
 	default:
 		return "<nil>"
 	}
 }
 
-// CommandTypeFromString looks up the given string as a CommandType.
+// CommandTypeFromString looks up the given string as a CommandType. The string can either be the name of a built-in
+// CommandType (i.e. "AppInfoPrint"), or the integer value of a CommandType alias registered using
+// RegisterCommandAlias. Aliases are resolved to their canonical CommandType using ResolveAlias.
 func CommandTypeFromString(s string) (CommandType, error) {
 	switch s {
 	case "AppInfoPrint":
 		return AppInfoPrint, nil
 	case "Quit":
 		return Quit, nil
+	case "AppInfoRequest":
+		return AppInfoRequest, nil
+	case "AppInfoUpdate":
+		return AppInfoUpdate, nil
+	case "AppUpdate":
+		return AppUpdate, nil
+	case "ForceInstallDir":
+		return ForceInstallDir, nil
+	case "Login":
+		return Login, nil
+	case "AppUninstall":
+		return AppUninstall, nil
+	case "AppStatus":
+		return AppStatus, nil
 	default:
+		if i, err := strconv.Atoi(s); err == nil {
+			if canonical := ResolveAlias(CommandType(i)); canonical != CommandType(i) {
+				return canonical, nil
+			}
+		}
 		return CommandType(0), fmt.Errorf("cannot get CommandType from \"%s\"", s)
 	}
 }
 
+// commandTypeFromSteamCMDString looks up the CommandType that has the given SteamCMD representation (i.e. the output of
+// CommandType.String) within the default Command bindings.
+func commandTypeFromSteamCMDString(s string) (CommandType, bool) {
+	for commandType := range commands {
+		if commandType.String() == s {
+			return commandType, true
+		}
+	}
+	return CommandType(0), false
+}
+
 // CommandOutputValidator validates whether a Command has completed successfully by validating the output of the
 // Command as well as which try the command is currently on.
 type CommandOutputValidator func(tryNo int, output []byte) bool
@@ -179,27 +370,157 @@ type Command struct {
 	Parser    CommandOutputParser
 	Validator CommandOutputValidator
 	Args      []*Arg
+	// Timeout is the amount of time to wait for the prompt after the Command has been sent to an interactive SteamCMD.
+	// If this is 0 then ExpectTimeout is used. This can be overridden for a SteamCMD by using WithCommandTimeout.
+	Timeout time.Duration
+}
+
+// variadicArg returns the last Arg in Args if it is Variadic. Otherwise, nil is returned.
+func (c *Command) variadicArg() *Arg {
+	if len(c.Args) > 0 && c.Args[len(c.Args)-1].Variadic {
+		return c.Args[len(c.Args)-1]
+	}
+	return nil
 }
 
-// Serialise will return the string that will be used to execute this Command via the steamcmd binary.
+// argAt returns the Arg that the arg at the given index will be serialised/validated by. If the index is beyond the
+// last Arg, then the Variadic Arg will be returned if there is one. Otherwise, nil is returned.
+func (c *Command) argAt(i int) *Arg {
+	if i < len(c.Args) {
+		return c.Args[i]
+	}
+	return c.variadicArg()
+}
+
+// Serialise will return the string that will be used to execute this Command via the steamcmd binary. If the last Arg
+// is Variadic, then any args beyond the last Arg will also be serialised using the Variadic Arg. Args that are
+// serialised to an empty string are skipped, so that optional flags can be omitted.
 func (c *Command) Serialise(args ...any) string {
 	command := []string{fmt.Sprintf("+%s", c.Type.String())}
-	if len(args) > 0 && len(c.Args) > 0 {
-		for i, arg := range c.Args {
-			if i < len(args) {
-				command = append(command, arg.Serialise(args[i]))
-			}
+	for i, value := range args {
+		arg := c.argAt(i)
+		if arg == nil {
+			break
+		}
+
+		if serialised := arg.Serialise(value); serialised != "" {
+			command = append(command, serialised)
 		}
 	}
 	return strings.Join(command, " ")
 }
 
+// SerialiseRedacted will serialise the Command with the given args in the same way as Serialise, but the values of any
+// Secret Arg will be replaced with Redacted. This should be used whenever a serialised Command is displayed.
+func (c *Command) SerialiseRedacted(args ...any) string {
+	return c.Serialise(c.redactArgs(args)...)
+}
+
+// redactArgs returns a copy of the given args where the values of any Secret Arg have been replaced with Redacted. The
+// given args are returned as is if the Command has no Secret Arg.
+func (c *Command) redactArgs(args []any) []any {
+	var redacted []any
+	for i := range args {
+		if arg := c.argAt(i); arg != nil && arg.Secret {
+			if redacted == nil {
+				redacted = append([]any{}, args...)
+			}
+			redacted[i] = Redacted
+		}
+	}
+
+	if redacted == nil {
+		return args
+	}
+	return redacted
+}
+
+// DeserialiseCommandLine will deserialise the given line into a CommandWithArgs. This is the inverse of
+// Command.Serialise, and the line can optionally be prefixed with a "+". I.e. "+app_info_print 477160" and
+// "app_info_print 477160" will both be deserialised to the AppInfoPrint Command with the arg int64(477160). Args are
+// separated by whitespace, and are parsed using ParseArgType if the corresponding Arg for the Command is a Number.
+//
+// Fields that match the Flag of an Arg are deserialised into the position of that Arg, and any skipped optional Arg
+// before it are given an empty string or a zero value. I.e. "app_update 740 -validate" will be deserialised to the
+// AppUpdate Command with the args int64(740), "", "", and int64(1).
+func DeserialiseCommandLine(line string) (*CommandWithArgs, error) {
+	fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(line), "+"))
+	if len(fields) == 0 {
+		return nil, errors.Errorf("cannot deserialise empty line %q to a command", line)
+	}
+
+	commandType, ok := commandTypeFromSteamCMDString(fields[0])
+	if !ok {
+		return nil, errors.Errorf("cannot find command %q in commands lookup", fields[0])
+	}
+	command := commands[commandType]
+
+	args := make([]any, 0, len(fields)-1)
+	for i := 1; i < len(fields); i++ {
+		field := fields[i]
+		if index, arg := command.argByFlag(field); arg != nil {
+			for j := len(args); j <= index; j++ {
+				if command.Args[j].Required && j != index {
+					return nil, errors.Errorf(
+						"required arg %q of command \"%s\" must be given before flag %q",
+						command.Args[j].Name, command.Type.String(), field,
+					)
+				}
+				args = append(args, command.Args[j].zeroValue())
+			}
+
+			switch {
+			case arg.Type == Number:
+				args[index] = int64(1)
+			case i+1 < len(fields):
+				i++
+				args[index] = fields[i]
+			default:
+				return nil, errors.Errorf("flag %q of command \"%s\" was not given a value", field, command.Type.String())
+			}
+			continue
+		}
+
+		var value any = field
+		if arg := command.argAt(len(args)); arg != nil && arg.Type == Number {
+			value, _ = ParseArgType(field)
+		}
+		args = append(args, value)
+	}
+
+	if !command.ValidateArgs(args...) {
+		return nil, errors.Errorf("command \"%s\" was given an invalid arg (%v)", command.Type.String(), args)
+	}
+	return &CommandWithArgs{Command: &command, Args: args}, nil
+}
+
+// argByFlag returns the index of the Arg within Args that has the given Flag, as well as the Arg itself. If there is no
+// such Arg then nil is returned.
+func (c *Command) argByFlag(flag string) (int, *Arg) {
+	for i, arg := range c.Args {
+		if arg.Flag != "" && strings.EqualFold(arg.Flag, flag) {
+			return i, arg
+		}
+	}
+	return -1, nil
+}
+
 // ValidateArgs will validate the given args against the Arg.Validator for each Arg in Args. If the number of args given
-// exceeds the number of Arg in Args, then this will count as invalid. If a required Arg is not provided, this will also
-// count as invalid.
+// exceeds the number of Arg in Args, then this will count as invalid, unless the last Arg is Variadic. In which case,
+// any args beyond the last Arg will be validated using the Variadic Arg. If a required Arg is not provided, this will
+// also count as invalid.
 func (c *Command) ValidateArgs(args ...any) bool {
 	if len(args) > len(c.Args) {
-		return false
+		variadic := c.variadicArg()
+		if variadic == nil {
+			return false
+		}
+
+		for _, value := range args[len(c.Args):] {
+			if !variadic.Validate(value) {
+				return false
+			}
+		}
 	}
 
 	valid := true
@@ -222,6 +543,38 @@ func (c *Command) ValidateArgs(args ...any) bool {
 	return valid
 }
 
+// ArgByName returns the Arg within Args that has the given Name. Names are matched case-insensitively.
+func (c *Command) ArgByName(name string) (*Arg, bool) {
+	for _, arg := range c.Args {
+		if strings.EqualFold(arg.Name, name) {
+			return arg, true
+		}
+	}
+	return nil, false
+}
+
+// RequiredArgs returns the Arg within Args that are Required.
+func (c *Command) RequiredArgs() []*Arg {
+	args := make([]*Arg, 0)
+	for _, arg := range c.Args {
+		if arg.Required {
+			args = append(args, arg)
+		}
+	}
+	return args
+}
+
+// OptionalArgs returns the Arg within Args that are not Required.
+func (c *Command) OptionalArgs() []*Arg {
+	args := make([]*Arg, 0)
+	for _, arg := range c.Args {
+		if !arg.Required {
+			args = append(args, arg)
+		}
+	}
+	return args
+}
+
 // Parse the Command's output using their Parser, if it is not nil. Otherwise, the output will just be converted to a
 // string and returned.
 func (c *Command) Parse(out []byte) (any, error) {
@@ -243,39 +596,151 @@ func (c *Command) ValidateOutput(tryNo int, out []byte) bool {
 	return c.Validator(tryNo, out)
 }
 
+var (
+	// appInfoObjectHeaderPattern matches the header line of each top-level object in the output of AppInfoPrint. I.e.
+	// the "477160" line that precedes the object for the app 477160.
+	appInfoObjectHeaderPattern = regexp.MustCompile(`(?m)^"\d+"\r?$`)
+	// appInfoFirstIDRe matches the first quoted ID within the output of AppInfoPrint, which is the header of the first
+	// object. Unlike appInfoObjectHeaderPattern, this does not need to be on its own line.
+	appInfoFirstIDRe = regexp.MustCompile(`"\d+"`)
+	// appInfoObjectRe matches the openings of objects within the output of AppInfoPrint.
+	appInfoObjectRe = regexp.MustCompile(`"([^"]+)"\r{0,2}\n\t+\{`)
+	// appInfoKVRe matches the key-value pairs within the output of AppInfoPrint.
+	appInfoKVRe = regexp.MustCompile(`"([^"]+)"\t\t"(([^\\]\\"|[^"])*?)"`)
+	// appInfoChangeNumberRe matches the change number line that signifies that the output of AppInfoPrint is complete.
+	appInfoChangeNumberRe = regexp.MustCompile(`, change number : [1-9]`)
+)
+
+// ErrMultipleAppInfoObjects is sent as the Event.Data of an EventParseWarning when the output of the AppInfoPrint
+// command contains multiple objects, as only the first object is parsed. ParseMultipleAppInfoOutputs can be used to
+// parse all the objects.
+type ErrMultipleAppInfoObjects struct {
+	// Objects is the number of objects that were found within the output.
+	Objects int
+}
+
+func (e ErrMultipleAppInfoObjects) Error() string {
+	return fmt.Sprintf("app_info_print output contains %d objects, but only the first was parsed", e.Objects)
+}
+
+// parseAppInfoPrint is the CommandOutputParser for the AppInfoPrint command. If the output contains multiple objects,
+// then only the first object is parsed, and SteamCMD will send an EventParseWarning with ErrMultipleAppInfoObjects.
+// ParseMultipleAppInfoOutputs can be used to parse all the objects.
+func parseAppInfoPrint(b []byte) (any, error) {
+	// SteamCMD object syntax (notice lack of ":"):
+	// "hello"
+	// {
+	//    "name"   "bob"
+	// }
+	b = bytes.Trim(b, " \t\r\n\x1b[1m\n")
+	// If there are multiple objects in the output, then we only parse the first one
+	if headers := appInfoObjectHeaderPattern.FindAllIndex(b, 2); len(headers) > 1 {
+		b = bytes.TrimSpace(b[:headers[1][0]])
+	}
+
+	indices := appInfoFirstIDRe.FindStringIndex(string(b))
+	if indices == nil {
+		return string(b), errors.New("cannot find the header of the app_info_print output")
+	}
+	// Remove the header of the response
+	jsonBody := strings.TrimSpace(string(b)[indices[1]+1:])
+	//fmt.Println("jsonBody 1", strings.Join(strings.Split(jsonBody, "\r\n")[:200], "\r\n"))
+	//fmt.Printf("jsonBody 1\n%q\n", jsonBody)
+	// Replace openings of json Objects with the correct syntax.
+	jsonBody = appInfoObjectRe.ReplaceAllString(jsonBody, "\"$1\": {")
+	//fmt.Println("jsonBody 2", strings.Join(strings.Split(jsonBody, "\r\n")[:200], "\r\n"))
+	//fmt.Printf("jsonBody 2\n%q\n", jsonBody)
+	// Replace key-value pairs with proper JSON syntax
+	jsonBody = appInfoKVRe.ReplaceAllString(jsonBody, "\"$1\": '''$2\n'''")
+	//fmt.Println("jsonBody 3", strings.Join(strings.Split(jsonBody, "\r\n")[:200], "\r\n"))
+	//fmt.Printf("jsonBody 3\n%q\n", jsonBody)
+
+	var json map[string]any
+	if err := hjson.Unmarshal([]byte(jsonBody), &json); err != nil {
+		return jsonBody, err
+	}
+
+	// Retired apps have an "app_retired_publisher_request" section instead of the usual sections
+	if _, retired := json["app_retired_publisher_request"]; retired {
+		if _, ok := json["common"]; !ok {
+			appID, _ := strconv.ParseInt(strings.Trim(string(b)[indices[0]:indices[1]], `"`), 10, 64)
+			return nil, ErrAppRetired{AppID: appID}
+		}
+	}
+	return json, nil
+}
+
+// ParseMultipleAppInfoOutputs parses an output of the AppInfoPrint command that contains multiple objects. This can
+// happen when printing the app info for a package ID. Each object is parsed using the same parser as the AppInfoPrint
+// command, and the parsed objects are returned in the order that they appear within the output.
+func ParseMultipleAppInfoOutputs(b []byte) ([]map[string]any, error) {
+	b = bytes.Trim(b, " \t\r\n\x1b[1m\n")
+	headers := appInfoObjectHeaderPattern.FindAllIndex(b, -1)
+	if len(headers) == 0 {
+		return nil, errors.New("cannot find any objects in the app_info_print output")
+	}
+
+	outputs := make([]map[string]any, len(headers))
+	for i, header := range headers {
+		end := len(b)
+		if i < len(headers)-1 {
+			end = headers[i+1][0]
+		}
+
+		parsedOutput, err := parseAppInfoPrint(b[header[0]:end])
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not parse object no. %d", i+1)
+		}
+		outputs[i] = parsedOutput.(map[string]any)
+	}
+	return outputs, nil
+}
+
+// appInfoAppIDLinePattern matches the "AppID : <appID>, change number : ..." line that precedes each object in the output
+// of AppInfoPrint.
+var appInfoAppIDLinePattern = regexp.MustCompile(`(?m)^AppID : (\d+),`)
+
+// appInfoOutputFor returns the part of the given output, which can contain the output of multiple AppInfoPrint
+// commands, that belongs to the app with the given ID. This is the object for the app, along with the "AppID : ..."
+// line that precedes it. If the output does not contain an object for the app then the output is returned unchanged.
+func appInfoOutputFor(b []byte, appID string) []byte {
+	headers := appInfoObjectHeaderPattern.FindAllIndex(b, -1)
+	for i, header := range headers {
+		if strings.Trim(strings.TrimSpace(string(b[header[0]:header[1]])), `"`) != appID {
+			continue
+		}
+
+		// Include the "AppID : ..." line, if there is one, between the previous object and this object
+		start, prevEnd := header[0], 0
+		if i > 0 {
+			prevEnd = headers[i-1][1]
+		}
+		if lines := appInfoAppIDLinePattern.FindAllIndex(b[prevEnd:header[0]], -1); len(lines) > 0 {
+			start = prevEnd + lines[len(lines)-1][0]
+		}
+
+		// The object ends at the next "AppID : ..." line, or the next object
+		end := len(b)
+		if i < len(headers)-1 {
+			end = headers[i+1][0]
+			if line := appInfoAppIDLinePattern.FindIndex(b[header[1]:end]); line != nil {
+				end = header[1] + line[0]
+			}
+		} else if line := appInfoAppIDLinePattern.FindIndex(b[header[1]:]); line != nil {
+			end = header[1] + line[0]
+		}
+		return b[start:end]
+	}
+	return b
+}
+
 // commands contains the default Command bindings for SteamCMD.
 var commands = map[CommandType]Command{
 	AppInfoPrint: {
-		Type: AppInfoPrint,
-		Parser: func(b []byte) (any, error) {
-			// SteamCMD object syntax (notice lack of ":"):
-			// "hello"
-			// {
-			//    "name"   "bob"
-			// }
-			b = bytes.Trim(b, " \t\r\n\x1b[1m\n")
-			indices := regexp.MustCompile(`"\d+"`).FindStringIndex(string(b))
-			// Remove the header of the response
-			jsonBody := strings.TrimSpace(string(b)[indices[1]+1:])
-			//fmt.Println("jsonBody 1", strings.Join(strings.Split(jsonBody, "\r\n")[:200], "\r\n"))
-			//fmt.Printf("jsonBody 1\n%q\n", jsonBody)
-			// Replace openings of json Objects with the correct syntax.
-			jsonBody = regexp.MustCompile(`"([^"]+)"\r{0,2}\n\t+\{`).ReplaceAllString(jsonBody, "\"$1\": {")
-			//fmt.Println("jsonBody 2", strings.Join(strings.Split(jsonBody, "\r\n")[:200], "\r\n"))
-			//fmt.Printf("jsonBody 2\n%q\n", jsonBody)
-			// Replace key-value pairs with proper JSON syntax
-			jsonBody = regexp.MustCompile(`"([^"]+)"\t\t"(([^\\]\\"|[^"])*?)"`).ReplaceAllString(jsonBody, "\"$1\": '''$2\n'''")
-			//fmt.Println("jsonBody 3", strings.Join(strings.Split(jsonBody, "\r\n")[:200], "\r\n"))
-			//fmt.Printf("jsonBody 3\n%q\n", jsonBody)
-
-			var json map[string]any
-			if err := hjson.Unmarshal([]byte(jsonBody), &json); err != nil {
-				return jsonBody, err
-			}
-			return json, nil
-		},
+		Type:   AppInfoPrint,
+		Parser: parseAppInfoPrint,
 		Validator: func(tryNo int, b []byte) bool {
-			return regexp.MustCompile(`, change number : [1-9]`).Match(b)
+			return appInfoChangeNumberRe.Match(b)
 		},
 		Args: []*Arg{
 			{
@@ -286,4 +751,262 @@ var commands = map[CommandType]Command{
 		},
 	},
 	Quit: {Type: Quit},
+	AppInfoRequest: {
+		Type: AppInfoRequest,
+		Validator: func(tryNo int, b []byte) bool {
+			// If steamcmd does not acknowledge the request, we stop retrying after appInfoRequestMaxTries so that we
+			// don't keep requesting the app info forever.
+			return appInfoRequestQueuedPattern.Match(b) || tryNo >= appInfoRequestMaxTries
+		},
+		Args: []*Arg{
+			{
+				Name:     "appid",
+				Type:     Number,
+				Required: true,
+			},
+		},
+	},
+	AppInfoUpdate: {
+		Type: AppInfoUpdate,
+		Args: []*Arg{
+			{
+				Name: "force",
+				Type: Number,
+			},
+		},
+	},
+	AppUpdate: {
+		Type:   AppUpdate,
+		Parser: parseAppUpdate,
+		Validator: func(tryNo int, b []byte) bool {
+			// A failed install is not retried, so that the Parser can return the error. We only retry up to
+			// appUpdateMaxTries times when neither the success nor the failure could be found in the output.
+			return appUpdateSuccessPattern.Match(b) || appUpdateFailedPattern.Match(b) || tryNo >= appUpdateMaxTries
+		},
+		Timeout: AppUpdateTimeout,
+		Args: []*Arg{
+			{
+				Name:     "appid",
+				Type:     Number,
+				Required: true,
+			},
+			{
+				Name: "beta",
+				Type: String,
+				Flag: "-beta",
+			},
+			{
+				Name: "betapassword",
+				Type: String,
+				Flag: "-betapassword",
+			},
+			{
+				Name: "validate",
+				Type: Number,
+				Flag: "-validate",
+			},
+		},
+	},
+	// steamcmd does not output anything useful after force_install_dir, so we use the default validator like Quit
+	ForceInstallDir: {
+		Type: ForceInstallDir,
+		Args: []*Arg{
+			{
+				Name:     "path",
+				Type:     String,
+				Required: true,
+			},
+		},
+	},
+	Login: {
+		Type:   Login,
+		Parser: parseLogin,
+		Validator: func(tryNo int, b []byte) bool {
+			// We don't keep retrying failed logins, as this can get the account rate limited
+			return loginOKPattern.Match(b) || loginSteamGuardPattern.Match(b) || loginFailedPattern.Match(b) ||
+				tryNo >= loginMaxTries
+		},
+		Args: []*Arg{
+			{
+				Name:     "username",
+				Type:     String,
+				Required: true,
+			},
+			{
+				Name:     "password",
+				Type:     String,
+				Required: true,
+				Secret:   true,
+			},
+			{
+				Name:   "steamguard",
+				Type:   String,
+				Secret: true,
+			},
+		},
+	},
+	AppUninstall: {
+		Type:   AppUninstall,
+		Parser: parseAppUninstall,
+		Validator: func(tryNo int, b []byte) bool {
+			// We never retry an uninstall, any failure will be reported by the Parser instead
+			return appUninstallStartedPattern.Match(b) || appUninstallCompletePattern.Match(b) || tryNo >= 1
+		},
+		Args: []*Arg{
+			{
+				Name:     "appid",
+				Type:     Number,
+				Required: true,
+			},
+			{
+				Name: "complete",
+				Type: String,
+				Validator: func(value any) bool {
+					s := strings.TrimPrefix(value.(string), "-")
+					return s == "" || strings.EqualFold(s, "complete")
+				},
+				Serialiser: func(value any) string {
+					if value.(string) != "" {
+						return "-complete"
+					}
+					return ""
+				},
+			},
+		},
+	},
+	AppStatus: {
+		Type:   AppStatus,
+		Parser: parseAppStatus,
+		Validator: func(tryNo int, b []byte) bool {
+			return appStatusHeaderPattern.Match(b) || tryNo >= appStatusMaxTries
+		},
+		Args: []*Arg{
+			{
+				Name:     "appid",
+				Type:     Number,
+				Required: true,
+			},
+		},
+	},
 }
+
+var (
+	// appUpdateSuccessPattern matches the output of the AppUpdate command when the app has been installed, or was
+	// already up to date.
+	appUpdateSuccessPattern = regexp.MustCompile(`Success! App '(\d+)' (fully installed|already up to date)`)
+	// appUpdateFailedPattern matches the output of the AppUpdate command when the app could not be installed. I.e.
+	// "ERROR! Failed to install app '740' (No subscription)".
+	appUpdateFailedPattern = regexp.MustCompile(`ERROR! Failed to install app '(\d+)' \(([^)]*)\)`)
+)
+
+// appUpdateMaxTries is the maximum number of times that the AppUpdate command is sent in interactive mode when its
+// output cannot be validated.
+const appUpdateMaxTries = 3
+
+// AppUpdateResult is the parsed output of the AppUpdate command.
+type AppUpdateResult struct {
+	// AppID is the ID of the app that was installed/updated. This will be 0 if it could not be found in the output.
+	AppID int64
+	// Success is whether the app was fully installed, or was already up to date.
+	Success bool
+	// EstimatedSize is the estimated size of the update in bytes. This is the largest total of any progress line in the
+	// output (see ParseUpdateProgress), and will be 0 if there were no progress lines. It can be given to CheckDiskSpace
+	// before retrying an update that failed.
+	EstimatedSize int64
+	// Output is the raw output of the AppUpdate command.
+	Output string
+}
+
+// parseAppUpdate is the CommandOutputParser for the AppUpdate command. The output is returned as an AppUpdateResult,
+// along with an error if the output contains an install failure.
+func parseAppUpdate(b []byte) (any, error) {
+	out := string(b)
+	result := AppUpdateResult{Output: out}
+	for _, line := range strings.Split(out, "\n") {
+		if progress, ok := ParseUpdateProgress(line); ok && progress.Total > result.EstimatedSize {
+			result.EstimatedSize = progress.Total
+		}
+	}
+
+	if groups := appUpdateSuccessPattern.FindStringSubmatch(out); groups != nil {
+		result.AppID, _ = strconv.ParseInt(groups[1], 10, 64)
+		result.Success = true
+		return result, nil
+	}
+
+	if groups := appUpdateFailedPattern.FindStringSubmatch(out); groups != nil {
+		result.AppID, _ = strconv.ParseInt(groups[1], 10, 64)
+		return result, errors.Errorf("could not install app %s: %s", groups[1], groups[2])
+	}
+	return result, nil
+}
+
+// anonymousUsername is the username that is given to the Login command to log in anonymously.
+const anonymousUsername = "anonymous"
+
+// isAnonymousLogin returns whether the given username is the anonymousUsername.
+func isAnonymousLogin(username string) bool {
+	return strings.EqualFold(username, anonymousUsername)
+}
+
+// ErrSteamGuardRequired is returned by the parser for the Login command when steamcmd asks for a Steam Guard code. The
+// Login command should be retried with the Steam Guard code as its third Arg.
+var ErrSteamGuardRequired = errors.New("Steam Guard code required to log in")
+
+var (
+	// loginOKPattern matches the output of the Login command when the login was successful.
+	loginOKPattern = regexp.MustCompile(`Logged in OK`)
+	// loginSteamGuardPattern matches the output of the Login command when a Steam Guard code is required.
+	loginSteamGuardPattern = regexp.MustCompile(`(?i)Steam Guard|Two-factor code`)
+	// loginSteamGuardPromptPattern matches the prompt for a Steam Guard code that steamcmd waits on in interactive
+	// mode, instead of the InteractivePrompt, when a Steam Guard code is required.
+	loginSteamGuardPromptPattern = regexp.MustCompile(`(?i)(?:Steam Guard|Two-factor) code:`)
+	// loginFailedPattern matches the output of the Login command when the login failed. I.e.
+	// "FAILED (Invalid Password)".
+	loginFailedPattern = regexp.MustCompile(`FAILED(?: \(([^)]*)\))?`)
+)
+
+// loginMaxTries is the maximum number of times that the Login command is sent in interactive mode when its output
+// cannot be validated.
+const loginMaxTries = 2
+
+// parseLogin is the CommandOutputParser for the Login command. The output is returned as a string, along with
+// ErrSteamGuardRequired if a Steam Guard code is required, or an error if the login failed.
+func parseLogin(b []byte) (any, error) {
+	out := string(b)
+	switch {
+	case loginOKPattern.MatchString(out):
+		return out, nil
+	case loginSteamGuardPattern.MatchString(out):
+		return out, ErrSteamGuardRequired
+	case loginFailedPattern.MatchString(out):
+		return out, errors.Errorf("login failed: %s", loginFailedPattern.FindStringSubmatch(out)[1])
+	default:
+		return out, errors.New("could not find the result of the login in the output")
+	}
+}
+
+var (
+	// appUninstallStartedPattern matches the output of the AppUninstall command when steamcmd has started to uninstall
+	// the app.
+	appUninstallStartedPattern = regexp.MustCompile(`Uninstalling App`)
+	// appUninstallCompletePattern matches the output of the AppUninstall command when the app has been uninstalled.
+	appUninstallCompletePattern = regexp.MustCompile(`Uninstall complete`)
+)
+
+// parseAppUninstall is the CommandOutputParser for the AppUninstall command. The output is returned as a string, along
+// with an error if steamcmd did not start uninstalling the app.
+func parseAppUninstall(b []byte) (any, error) {
+	out := string(b)
+	if !appUninstallStartedPattern.MatchString(out) {
+		return out, errors.New("app was not uninstalled, as \"Uninstalling App\" could not be found in the output")
+	}
+	return out, nil
+}
+
+// appInfoRequestQueuedPattern matches the output of the AppInfoRequest command when the request has been queued.
+var appInfoRequestQueuedPattern = regexp.MustCompile(`(?i)(requesting|queued|pending)`)
+
+// appInfoRequestMaxTries is the maximum number of times that the AppInfoRequest command is sent in interactive mode
+// when its output cannot be validated.
+const appInfoRequestMaxTries = 3
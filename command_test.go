@@ -0,0 +1,286 @@
+package steamcmd
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func ExampleNewEnumArg() {
+	command := Command{
+		Type: AppInfoPrint,
+		Args: []*Arg{NewEnumArg("platform", "windows", "linux", "macos")},
+	}
+	fmt.Println(command.ValidateArgs("Linux"), command.Serialise("Linux"))
+	fmt.Println(command.ValidateArgs("android"))
+	fmt.Println(command.ValidateArgs(1))
+	fmt.Println(int(Enum), Enum)
+	// Output:
+	// true +app_info_print linux
+	// false
+	// false
+	// 3 Enum
+}
+
+func ExampleCommand_ArgByName() {
+	command := Command{
+		Type: AppInfoPrint,
+		Args: []*Arg{
+			{Name: "appid", Type: Number, Required: true},
+			NewEnumArg("platform", "windows", "linux", "macos"),
+		},
+	}
+	arg, ok := command.ArgByName("AppID")
+	fmt.Println(arg.Name, ok)
+	arg, ok = command.ArgByName("beta")
+	fmt.Println(arg, ok)
+	fmt.Println(len(command.RequiredArgs()), command.RequiredArgs()[0].Name)
+	fmt.Println(len(command.OptionalArgs()), command.OptionalArgs()[0].Name)
+	// Output:
+	// appid true
+	// <nil> false
+	// 1 appid
+	// 1 platform
+}
+
+func ExampleArg_Variadic() {
+	command := Command{
+		Type: AppInfoPrint,
+		Args: []*Arg{
+			{Name: "platform", Type: String, Required: true},
+			{Name: "appids", Type: Number, Variadic: true},
+		},
+	}
+	fmt.Println(command.ValidateArgs("windows"), command.Serialise("windows"))
+	fmt.Println(command.ValidateArgs("windows", 10, 20, 30), command.Serialise("windows", 10, 20, 30))
+	fmt.Println(command.ValidateArgs("windows", 10, "20"))
+	// Output:
+	// true +app_info_print windows
+	// true +app_info_print windows 10 20 30
+	// false
+}
+
+func ExampleAppUpdate() {
+	command := NewCommandWithArgs(AppUpdate).Command
+	fmt.Println(command.Serialise(740))
+	fmt.Println(command.Serialise(740, "prerelease", "hunter2"))
+	fmt.Println(command.Serialise(740, "", "", 1))
+	fmt.Println(command.ValidateArgs(740, "prerelease", "", 1), command.ValidateArgs("prerelease"))
+	// Output:
+	// +app_update 740
+	// +app_update 740 -beta prerelease -betapassword hunter2
+	// +app_update 740 -validate
+	// true false
+}
+
+func TestDeserialiseCommandLine_Flags(t *testing.T) {
+	for testNo, test := range []struct {
+		line         string
+		expectedArgs []any
+		expectedErr  bool
+	}{
+		{"app_update 740", []any{int64(740)}, false},
+		{"+app_update 740 -validate", []any{int64(740), "", "", int64(1)}, false},
+		{"app_update 740 -beta prerelease", []any{int64(740), "prerelease"}, false},
+		{"app_update 740 -beta prerelease -betapassword hunter2", []any{int64(740), "prerelease", "hunter2"}, false},
+		{"app_update 740 -betapassword hunter2 -validate", []any{int64(740), "", "hunter2", int64(1)}, false},
+		{"app_update 740 -beta", nil, true},
+		{"app_update -validate", nil, true},
+	} {
+		command, err := DeserialiseCommandLine(test.line)
+		if (err != nil) != test.expectedErr {
+			t.Errorf("%d: expected an error: %t, got %v", testNo+1, test.expectedErr, err)
+			continue
+		}
+
+		if test.expectedErr {
+			continue
+		}
+
+		if !reflect.DeepEqual(command.Args, test.expectedArgs) {
+			t.Errorf("%d: expected args %v, got %v", testNo+1, test.expectedArgs, command.Args)
+		}
+
+		if serialised := command.Command.Serialise(command.Args...); serialised != "+"+strings.TrimPrefix(test.line, "+") {
+			t.Errorf("%d: expected %q to serialise back to itself, got %q", testNo+1, test.line, serialised)
+		}
+	}
+}
+
+func TestAppUpdateOutput(t *testing.T) {
+	const progress = "Update state (0x61) downloading, progress: 12.34 (123 / 999)\n"
+	for testNo, test := range []struct {
+		output         string
+		tryNo          int
+		expectedValid  bool
+		expectedErr    bool
+		expectedResult AppUpdateResult
+	}{
+		{
+			progress + "Success! App '740' fully installed.", 1, true, false,
+			AppUpdateResult{AppID: 740, Success: true, EstimatedSize: 999},
+		},
+		{"Success! App '740' already up to date.", 1, true, false, AppUpdateResult{AppID: 740, Success: true}},
+		{progress, 1, false, false, AppUpdateResult{EstimatedSize: 999}},
+		{
+			progress + "Update state (0x5) verifying install, progress: 50.00 (500 / 1000)\n" +
+				"ERROR! Failed to install app '740' (Disk write failure)", 1, true, true,
+			AppUpdateResult{AppID: 740, EstimatedSize: 1000},
+		},
+		{"ERROR! Failed to install app '740' (No subscription)", 3, true, true, AppUpdateResult{AppID: 740}},
+	} {
+		command := NewCommandWithArgs(AppUpdate).Command
+		if valid := command.ValidateOutput(test.tryNo, []byte(test.output)); valid != test.expectedValid {
+			t.Errorf("%d: expected output to be valid: %t, got %t", testNo+1, test.expectedValid, valid)
+		}
+
+		output, err := command.Parse([]byte(test.output))
+		if (err != nil) != test.expectedErr {
+			t.Errorf("%d: expected an error: %t, got %v", testNo+1, test.expectedErr, err)
+		}
+
+		test.expectedResult.Output = test.output
+		if !reflect.DeepEqual(output, test.expectedResult) {
+			t.Errorf("%d: expected result %+v, got %+v", testNo+1, test.expectedResult, output)
+		}
+	}
+}
+
+func TestLoginOutput(t *testing.T) {
+	const loggingIn = "Logging in user 'bob' to Steam Public..."
+	for testNo, test := range []struct {
+		output        string
+		tryNo         int
+		expectedValid bool
+		expectedErr   string
+	}{
+		{loggingIn + "OK\nLogged in OK", 1, true, ""},
+		{loggingIn + "\nThis computer has not been authenticated. Steam Guard code:", 1, true, ErrSteamGuardRequired.Error()},
+		{loggingIn + "FAILED (Invalid Password)", 1, true, "login failed: Invalid Password"},
+		{loggingIn, 1, false, "could not find the result of the login in the output"},
+		{loggingIn, 2, true, "could not find the result of the login in the output"},
+	} {
+		command := NewCommandWithArgs(Login).Command
+		if valid := command.ValidateOutput(test.tryNo, []byte(test.output)); valid != test.expectedValid {
+			t.Errorf("%d: expected output to be valid: %t, got %t", testNo+1, test.expectedValid, valid)
+		}
+
+		_, err := command.Parse([]byte(test.output))
+		errString := ""
+		if err != nil {
+			errString = err.Error()
+		}
+
+		if errString != test.expectedErr {
+			t.Errorf("%d: expected error %q, got %v", testNo+1, test.expectedErr, err)
+		}
+	}
+}
+
+func ExampleAppUninstall() {
+	command := NewCommandWithArgs(AppUninstall).Command
+	fmt.Println(command.ValidateArgs(740), command.Serialise(740))
+	fmt.Println(command.ValidateArgs(740, "complete"), command.Serialise(740, "complete"))
+	fmt.Println(command.ValidateArgs(740, "-force"))
+	out := []byte("Uninstalling App '740'...\nUninstall complete.")
+	_, err := command.Parse(out)
+	fmt.Println(command.ValidateOutput(1, out), err)
+	// Failures are not retried, and are reported by the parser instead
+	out = []byte("ERROR! App '740' is not installed")
+	_, err = command.Parse(out)
+	fmt.Println(command.ValidateOutput(1, out), err != nil)
+	// Output:
+	// true +app_uninstall 740
+	// true +app_uninstall 740 -complete
+	// false
+	// true <nil>
+	// true true
+}
+
+func TestAppInfoPrintParser(t *testing.T) {
+	for testNo, test := range []struct {
+		fixture         string
+		expectedRetired bool
+		expectedAppID   int64
+	}{
+		{"testdata/app_info_print_477160.txt", false, 477160},
+		{"testdata/app_info_print_retired.txt", true, 1234560},
+	} {
+		input, err := os.ReadFile(test.fixture)
+		if err != nil {
+			t.Fatalf("%d: could not read fixture %s: %v", testNo+1, test.fixture, err)
+		}
+
+		var output any
+		output, err = NewCommandWithArgs(AppInfoPrint).Command.Parse(input)
+		if retired := AppIsRetired(errors.Wrap(err, "wrapped")); retired != test.expectedRetired {
+			t.Errorf("%d: expected AppIsRetired to be %t, got %t (err: %v)", testNo+1, test.expectedRetired, retired, err)
+		}
+
+		var appID int64
+		if test.expectedRetired {
+			var retiredErr ErrAppRetired
+			if errors.As(err, &retiredErr) {
+				appID = retiredErr.AppID
+			}
+		} else {
+			if err != nil {
+				t.Errorf("%d: unexpected error: %v", testNo+1, err)
+				continue
+			}
+			appID, _ = AppID(output.(map[string]any))
+		}
+
+		if appID != test.expectedAppID {
+			t.Errorf("%d: expected app ID %d, got %d", testNo+1, test.expectedAppID, appID)
+		}
+	}
+}
+
+func TestParseMultipleAppInfoOutputs(t *testing.T) {
+	input, err := os.ReadFile("testdata/app_info_print_477160.txt")
+	if err != nil {
+		t.Fatalf("could not read fixture: %v", err)
+	}
+	// Append a second object to the fixture
+	input = append(input, []byte("\"620\"\n{\n\t\"common\"\n\t{\n\t\t\"name\"\t\t\"Portal 2\"\n\t}\n}\n")...)
+
+	var outputs []map[string]any
+	if outputs, err = ParseMultipleAppInfoOutputs(input); err != nil {
+		t.Fatalf("could not parse multiple outputs: %v", err)
+	}
+
+	names := make([]string, len(outputs))
+	for i, output := range outputs {
+		names[i], _ = AppName(output)
+	}
+	if !reflect.DeepEqual(names, []string{"Human: Fall Flat", "Portal 2"}) {
+		t.Errorf("expected names [Human: Fall Flat Portal 2], got %v", names)
+	}
+
+	// The single object parser should only parse the first object
+	var output any
+	if output, err = NewCommandWithArgs(AppInfoPrint).Command.Parse(input); err != nil {
+		t.Fatalf("could not parse output: %v", err)
+	}
+	if name, _ := AppName(output.(map[string]any)); name != "Human: Fall Flat" {
+		t.Errorf("expected name %q, got %q", "Human: Fall Flat", name)
+	}
+
+	// A SteamCMD should warn that the second object was ignored
+	sc := New(true)
+	warnings := sc.Subscribe(EventParseWarning)
+	if _, err = sc.parseOutput(NewCommandWithArgs(AppInfoPrint).Command, input); err != nil {
+		t.Fatalf("could not parse output: %v", err)
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("expected 1 %s event, got %d", EventParseWarning.String(), len(warnings))
+	}
+	if warning := <-warnings; !reflect.DeepEqual(warning.Data, ErrMultipleAppInfoObjects{Objects: 2}) {
+		t.Errorf("expected event data %v, got %v", ErrMultipleAppInfoObjects{Objects: 2}, warning.Data)
+	}
+}
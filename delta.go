@@ -0,0 +1,84 @@
+package steamcmd
+
+import (
+	"github.com/andygello555/agem"
+	"github.com/pkg/errors"
+)
+
+// parseAppInfoProbe is the CommandOutputParser for the probe Command that is used by SteamCMD.AppInfoPrintDelta. Only
+// the change number is extracted from the header line within the output of the AppInfoRequest command. If there is no
+// header line, then the change number is unknown and 0 is returned.
+func parseAppInfoProbe(b []byte) (any, error) {
+	changeNumber, err := HeaderChangeNumber(b)
+	if err != nil {
+		return int64(0), nil
+	}
+	return changeNumber, nil
+}
+
+// AppInfoPrintDelta will execute the AppInfoPrint command for each of the given app IDs whose change number has
+// increased since the change number given for them in previous. Apps that do not have an entry in previous are always
+// printed. The IDs of the apps that have changed are returned, along with their parsed outputs keyed by their app ID.
+// Apps that have not changed are not included in either.
+//
+// The change number of each app is probed first using the AppInfoRequest command, which is read from the header line
+// ("AppID : 477160, change number : 16411497/0, ...") that steamcmd outputs once the request has completed. This
+// means that AppInfoPrint is only executed for apps that have changed. If steamcmd does not output the change number
+// for an app, then AppInfoPrint will be executed for it regardless. The entries within SteamCMD.ParsedOutputs for each
+// AppInfoRequest probe will be nil.
+//
+// SteamCMD must be in interactive mode and must have been started. Like BulkAppInfoPrint, an error for one app will not
+// stop the other apps from being printed, and the errors are merged into the returned error.
+func (sc *SteamCMD) AppInfoPrintDelta(appIDs []int64, previous map[int64]int64) (changed []int64, outputs map[int64]map[string]any, err error) {
+	if !sc.interactive {
+		return nil, nil, errors.New("AppInfoPrintDelta can only be used when SteamCMD is in interactive mode")
+	}
+
+	request := NewCommandWithArgs(AppInfoRequest).Command
+	probe := *request
+	probe.Parser = parseAppInfoProbe
+	probe.Validator = func(tryNo int, b []byte) bool {
+		return appInfoHeaderChangeNumberPattern.Match(b) || request.ValidateOutput(tryNo, b)
+	}
+
+	errs := make([]error, 0)
+	toPrint := make([]int64, 0, len(appIDs))
+	for _, appID := range appIDs {
+		if err = sc.AddCommand(&probe, appID); err != nil {
+			errs = append(errs, errors.Wrapf(err, "could not probe change number of app info for %d", appID))
+			continue
+		}
+
+		i := len(sc.ParsedOutputs) - 1
+		changeNumber, _ := sc.ParsedOutputs[i].(int64)
+		sc.ParsedOutputs[i] = nil
+		// A change number of 0 means that it could not be probed, so we print the app info just in case
+		if previousChangeNumber, seen := previous[appID]; seen && changeNumber > 0 &&
+			changeNumber <= previousChangeNumber {
+			continue
+		}
+		toPrint = append(toPrint, appID)
+	}
+
+	changed = make([]int64, 0, len(toPrint))
+	outputs = make(map[int64]map[string]any)
+	for _, appID := range toPrint {
+		if err = sc.AddCommandType(AppInfoPrint, appID); err != nil {
+			errs = append(errs, errors.Wrapf(err, "could not print app info for %d", appID))
+			continue
+		}
+
+		parsedOutput := sc.ParsedOutputs[len(sc.ParsedOutputs)-1]
+		output, ok := parsedOutput.(map[string]any)
+		if !ok {
+			errs = append(errs, errors.Errorf(
+				"parsed output of AppInfoPrint for %d is not a map, it is a %T",
+				appID, parsedOutput,
+			))
+			continue
+		}
+		changed = append(changed, appID)
+		outputs[appID] = output
+	}
+	return changed, outputs, agem.MergeErrors(errs...)
+}
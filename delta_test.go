@@ -0,0 +1,73 @@
+package steamcmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSteamCMD_AppInfoPrintDelta(t *testing.T) {
+	changedOutput := strings.NewReplacer(
+		"477160", "620",
+		"16411497", "16411600",
+		"Human: Fall Flat", "Portal 2",
+	).Replace(sampleAppInfoPrintOutput)
+	requestOutput := func(appID int, changeNumber int) []byte {
+		return []byte(fmt.Sprintf(
+			"Requesting appinfo update for %[1]d\nAppID : %[1]d, change number : %[2]d/0, last change : Fri Oct  7 14:32:33 2022",
+			appID, changeNumber,
+		))
+	}
+
+	// The change number of 477160 has not increased, 620 has increased, and 730 could not be probed
+	rs := &RecordedSession{Commands: []RecordedCommand{
+		{CommandType: AppInfoRequest, Args: []any{477160}, Output: requestOutput(477160, 16411497)},
+		{CommandType: AppInfoRequest, Args: []any{620}, Output: requestOutput(620, 16411600)},
+		{CommandType: AppInfoRequest, Args: []any{730}, Output: []byte("Requesting appinfo update for 730")},
+		{CommandType: AppInfoPrint, Args: []any{620}, Output: []byte(changedOutput)},
+		{CommandType: AppInfoPrint, Args: []any{730}, Output: []byte(sampleAppInfoPrintOutput)},
+	}}
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := rs.Save(path); err != nil {
+		t.Fatalf("could not save recorded session: %v", err)
+	}
+
+	sc, err := NewReplaySession(path)
+	if err != nil {
+		t.Fatalf("could not create replay session: %v", err)
+	}
+	if err = sc.Start(); err != nil {
+		t.Fatalf("could not start replay session: %v", err)
+	}
+
+	changed, outputs, err := sc.AppInfoPrintDelta([]int64{477160, 620, 730}, map[int64]int64{
+		477160: 16411497,
+		620:    16411497,
+	})
+	if err != nil {
+		t.Fatalf("could not print app info delta: %v", err)
+	}
+
+	if !reflect.DeepEqual(changed, []int64{620, 730}) {
+		t.Errorf("expected changed apps %v, got %v", []int64{620, 730}, changed)
+	}
+
+	if len(outputs) != 2 {
+		t.Errorf("expected 2 outputs, got %d", len(outputs))
+	}
+
+	if name, _ := AppName(outputs[620]); name != "Portal 2" {
+		t.Errorf("expected name %q for 620, got %q", "Portal 2", name)
+	}
+
+	if len(sc.ParsedOutputs) != 5 || sc.ParsedOutputs[0] != nil {
+		t.Errorf("expected 3 nil probe outputs followed by 2 app info outputs, got %v", sc.ParsedOutputs)
+	}
+
+	if _, _, err = New(false).AppInfoPrintDelta([]int64{477160}, nil); err == nil {
+		t.Errorf("expected an error for a non-interactive SteamCMD")
+	}
+}
@@ -0,0 +1,34 @@
+package steamcmd
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+)
+
+// ErrInsufficientDiskSpace is returned by CheckDiskSpace when there is not enough space available on the disk.
+type ErrInsufficientDiskSpace struct {
+	// Available is the number of bytes that are available on the disk.
+	Available int64
+	// Required is the number of bytes that were required.
+	Required int64
+}
+
+func (e ErrInsufficientDiskSpace) Error() string {
+	return fmt.Sprintf("insufficient disk space: %d bytes available, %d bytes required", e.Available, e.Required)
+}
+
+// CheckDiskSpace checks whether the disk that the given installDir is located on has at least requiredBytes of free
+// space available. This is useful to check before running a potentially large app_update, and the
+// AppUpdateResult.EstimatedSize of a previous AppUpdate can be given as the requiredBytes. If there is not enough free
+// space then ErrInsufficientDiskSpace is returned.
+func CheckDiskSpace(installDir string, requiredBytes int64) error {
+	available, err := availableDiskSpace(installDir)
+	if err != nil {
+		return errors.Wrapf(err, "could not find available disk space for %q", installDir)
+	}
+
+	if available < requiredBytes {
+		return ErrInsufficientDiskSpace{Available: available, Required: requiredBytes}
+	}
+	return nil
+}
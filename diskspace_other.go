@@ -0,0 +1,13 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !windows
+
+package steamcmd
+
+import (
+	"github.com/pkg/errors"
+	"runtime"
+)
+
+// availableDiskSpace is not supported on this platform.
+func availableDiskSpace(path string) (int64, error) {
+	return 0, errors.Errorf("checking available disk space is not supported on %s", runtime.GOOS)
+}
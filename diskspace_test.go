@@ -0,0 +1,25 @@
+package steamcmd
+
+import (
+	"github.com/pkg/errors"
+	"math"
+	"testing"
+)
+
+func TestCheckDiskSpace(t *testing.T) {
+	dir := t.TempDir()
+	if err := CheckDiskSpace(dir, 1); err != nil {
+		t.Errorf("expected no error when requiring 1 byte, got %v", err)
+	}
+
+	var insufficient ErrInsufficientDiskSpace
+	if err := CheckDiskSpace(dir, math.MaxInt64); !errors.As(err, &insufficient) {
+		t.Errorf("expected ErrInsufficientDiskSpace when requiring %d bytes, got %v", int64(math.MaxInt64), err)
+	} else if insufficient.Required != math.MaxInt64 || insufficient.Available <= 0 {
+		t.Errorf("unexpected ErrInsufficientDiskSpace: %+v", insufficient)
+	}
+
+	if err := CheckDiskSpace(dir+"/does/not/exist", 1); err == nil {
+		t.Errorf("expected an error for a directory that does not exist, got nil")
+	}
+}
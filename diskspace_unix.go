@@ -0,0 +1,15 @@
+//go:build darwin || dragonfly || freebsd || linux
+
+package steamcmd
+
+import "syscall"
+
+// availableDiskSpace returns the number of bytes available to an unprivileged user on the disk containing the given
+// path.
+func availableDiskSpace(path string) (int64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return int64(stat.Bavail) * int64(stat.Bsize), nil
+}
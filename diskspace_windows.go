@@ -0,0 +1,29 @@
+//go:build windows
+
+package steamcmd
+
+import (
+	"syscall"
+	"unsafe"
+)
+
+var getDiskFreeSpaceExW = syscall.NewLazyDLL("kernel32.dll").NewProc("GetDiskFreeSpaceExW")
+
+// availableDiskSpace returns the number of bytes available to the current user on the disk containing the given path.
+func availableDiskSpace(path string) (int64, error) {
+	pathPtr, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return 0, err
+	}
+
+	var freeBytesAvailable, totalBytes, totalFreeBytes uint64
+	if ok, _, err := getDiskFreeSpaceExW.Call(
+		uintptr(unsafe.Pointer(pathPtr)),
+		uintptr(unsafe.Pointer(&freeBytesAvailable)),
+		uintptr(unsafe.Pointer(&totalBytes)),
+		uintptr(unsafe.Pointer(&totalFreeBytes)),
+	); ok == 0 {
+		return 0, err
+	}
+	return int64(freeBytesAvailable), nil
+}
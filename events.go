@@ -0,0 +1,124 @@
+package steamcmd
+
+import "time"
+
+// DefaultEventBufferSize is the buffer size of the channels returned by SteamCMD.Subscribe. This can be overridden using
+// WithEventBufferSize.
+const DefaultEventBufferSize = 16
+
+// EventType is the type of Event that is sent to subscribers of a SteamCMD.
+type EventType int
+
+const (
+	// EventCommandQueued is sent when a Command is queued/executed using SteamCMD.AddCommand. The Event.Data will be
+	// the *CommandWithArgs that was queued.
+	EventCommandQueued EventType = iota
+	// EventCommandExecuted is sent when a Command has finished executing. The Event.Data will be the ExecutionTrace
+	// for the Command. In non-interactive mode, this is sent for each Command after the SteamCMD process has exited.
+	EventCommandExecuted
+	// EventSessionStarted is sent when the SteamCMD process is started.
+	EventSessionStarted
+	// EventSessionClosed is sent when the SteamCMD has been closed without error.
+	EventSessionClosed
+	// EventReconnecting is sent when an interactive SteamCMD process has stalled and is being restarted.
+	EventReconnecting
+	// EventParseWarning is sent when the output of a Command was parsed, but some of the output was ignored. The
+	// Event.Data will be an error describing what was ignored. I.e. ErrMultipleAppInfoObjects.
+	EventParseWarning
+)
+
+// String returns the name of the EventType.
+func (et EventType) String() string {
+	switch et {
+	case EventCommandQueued:
+		return "CommandQueued"
+	case EventCommandExecuted:
+		return "CommandExecuted"
+	case EventSessionStarted:
+		return "SessionStarted"
+	case EventSessionClosed:
+		return "SessionClosed"
+	case EventReconnecting:
+		return "Reconnecting"
+	case EventParseWarning:
+		return "ParseWarning"
+	default:
+		return "<nil>"
+	}
+}
+
+// Event is sent to the channels returned by SteamCMD.Subscribe.
+type Event struct {
+	// Type is the EventType of the Event.
+	Type EventType
+	// Time is when the Event occurred.
+	Time time.Time
+	// Data is any additional data for the Event. See the documentation for each EventType for what this will be.
+	Data any
+}
+
+// subscriber is a channel returned by SteamCMD.Subscribe, along with the EventType(s) it is subscribed to.
+type subscriber struct {
+	ch     chan Event
+	events map[EventType]struct{}
+}
+
+// Subscribe returns a channel that will receive an Event for each of the given EventType(s). If no EventType(s) are
+// given, then the channel will receive every Event. Every subscriber receives the same events.
+//
+// The channel has a buffer size of DefaultEventBufferSize (or the size given to WithEventBufferSize). If the buffer of
+// the channel is full when an Event is sent then the Event will be dropped for that channel, so that a slow subscriber
+// cannot block the SteamCMD. The channel is closed when SteamCMD is closed, or when it is given to
+// SteamCMD.Unsubscribe.
+func (sc *SteamCMD) Subscribe(events ...EventType) <-chan Event {
+	sub := &subscriber{
+		ch:     make(chan Event, sc.eventBufferSize),
+		events: make(map[EventType]struct{}),
+	}
+	for _, event := range events {
+		sub.events[event] = struct{}{}
+	}
+
+	sc.subscribersMu.Lock()
+	defer sc.subscribersMu.Unlock()
+	sc.subscribers = append(sc.subscribers, sub)
+	return sub.ch
+}
+
+// Unsubscribe will close and remove the given channel that was returned by SteamCMD.Subscribe.
+func (sc *SteamCMD) Unsubscribe(ch <-chan Event) {
+	sc.subscribersMu.Lock()
+	defer sc.subscribersMu.Unlock()
+	for i, sub := range sc.subscribers {
+		if (<-chan Event)(sub.ch) == ch {
+			close(sub.ch)
+			sc.subscribers = append(sc.subscribers[:i], sc.subscribers[i+1:]...)
+			return
+		}
+	}
+}
+
+// publish sends an Event with the given EventType and data to all subscribers that are subscribed to the EventType.
+func (sc *SteamCMD) publish(eventType EventType, data any) {
+	sc.subscribersMu.Lock()
+	defer sc.subscribersMu.Unlock()
+	event := Event{Type: eventType, Time: time.Now(), Data: data}
+	for _, sub := range sc.subscribers {
+		if _, ok := sub.events[eventType]; ok || len(sub.events) == 0 {
+			select {
+			case sub.ch <- event:
+			default:
+			}
+		}
+	}
+}
+
+// unsubscribeAll will close and remove all the channels returned by SteamCMD.Subscribe.
+func (sc *SteamCMD) unsubscribeAll() {
+	sc.subscribersMu.Lock()
+	defer sc.subscribersMu.Unlock()
+	for _, sub := range sc.subscribers {
+		close(sub.ch)
+	}
+	sc.subscribers = nil
+}
@@ -0,0 +1,51 @@
+package steamcmd
+
+import "testing"
+
+func TestSteamCMD_Subscribe(t *testing.T) {
+	sc := New(false, WithEventBufferSize(2))
+	all := sc.Subscribe()
+	queued := sc.Subscribe(EventCommandQueued)
+	closed := sc.Subscribe(EventSessionClosed)
+
+	for i := 0; i < 3; i++ {
+		if err := sc.AddCommandType(AppInfoPrint, 477160); err != nil {
+			t.Fatalf("unexpected error whilst adding command no. %d: %v", i, err)
+		}
+	}
+
+	// The third event should have been dropped as the buffer size is 2
+	for _, ch := range []<-chan Event{all, queued} {
+		if len(ch) != 2 {
+			t.Errorf("expected 2 buffered events, got %d", len(ch))
+		}
+
+		for i := 0; i < 2; i++ {
+			event := <-ch
+			if event.Type != EventCommandQueued {
+				t.Errorf("expected %s event, got %s", EventCommandQueued.String(), event.Type.String())
+			}
+
+			if command, ok := event.Data.(*CommandWithArgs); !ok || command.Command.Type != AppInfoPrint {
+				t.Errorf("expected event data to be the queued AppInfoPrint command, got %v", event.Data)
+			}
+		}
+	}
+
+	if len(closed) != 0 {
+		t.Errorf("expected no events for a subscriber only subscribed to %s, got %d", EventSessionClosed.String(), len(closed))
+	}
+
+	sc.Unsubscribe(queued)
+	if _, ok := <-queued; ok {
+		t.Errorf("expected channel to be closed after unsubscribing")
+	}
+
+	if err := sc.AddCommandType(AppInfoPrint, 477160); err != nil {
+		t.Fatalf("unexpected error whilst adding command: %v", err)
+	}
+
+	if len(all) != 1 {
+		t.Errorf("expected remaining subscribers to still receive events, got %d events", len(all))
+	}
+}
@@ -0,0 +1,51 @@
+package steamcmd
+
+import (
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrCommandFailure is returned by ExitCodeToError when steamcmd exits with a generic failure.
+	ErrCommandFailure = errors.New("steamcmd command failed")
+	// ErrNetworkFailure is returned by ExitCodeToError when steamcmd cannot connect to Steam.
+	ErrNetworkFailure = errors.New("steamcmd network failure")
+	// ErrAuthFailure is returned by ExitCodeToError when steamcmd cannot log in to Steam.
+	ErrAuthFailure = errors.New("steamcmd authentication failure")
+	// ErrInsufficientDisk is returned by ExitCodeToError when steamcmd does not have enough disk space to install an app.
+	// CheckDiskSpace can be used to check for this before running steamcmd.
+	ErrInsufficientDisk = errors.New("steamcmd has insufficient disk space")
+	// ErrUnknownExitCode is returned by ExitCodeToError when steamcmd exits with an exit code that is not known.
+	ErrUnknownExitCode = errors.New("steamcmd exited with an unknown exit code")
+)
+
+// exitCodeErrors are the known non-zero exit codes of steamcmd mapped to their errors.
+var exitCodeErrors = map[int]error{
+	1: ErrCommandFailure,
+	3: ErrNetworkFailure,
+	5: ErrNetworkFailure,
+	6: ErrAuthFailure,
+	8: ErrInsufficientDisk,
+}
+
+// ExitCodeToError maps the given exit code of the steamcmd process to an error that can be checked using errors.Is. The
+// known exit codes are:
+//
+//	Code | Error               | Meaning
+//	0    | nil                 | Success
+//	1    | ErrCommandFailure   | Generic failure
+//	3    | ErrNetworkFailure   | No connection to Steam
+//	5    | ErrNetworkFailure   | Network failure
+//	6    | ErrAuthFailure      | Could not log in
+//	8    | ErrInsufficientDisk | Not enough disk space to install the app
+//
+// Any other exit code will return ErrUnknownExitCode. The returned error will always contain the exit code.
+func ExitCodeToError(code int) error {
+	if code == 0 {
+		return nil
+	}
+
+	if err, ok := exitCodeErrors[code]; ok {
+		return errors.Wrapf(err, "steamcmd exited with code %d", code)
+	}
+	return errors.Wrapf(ErrUnknownExitCode, "steamcmd exited with code %d", code)
+}
@@ -0,0 +1,33 @@
+package steamcmd
+
+import (
+	"github.com/pkg/errors"
+	"testing"
+)
+
+func TestExitCodeToError(t *testing.T) {
+	for testNo, test := range []struct {
+		code        int
+		expectedErr error
+	}{
+		{0, nil},
+		{1, ErrCommandFailure},
+		{3, ErrNetworkFailure},
+		{5, ErrNetworkFailure},
+		{6, ErrAuthFailure},
+		{8, ErrInsufficientDisk},
+		{42, ErrUnknownExitCode},
+	} {
+		err := ExitCodeToError(test.code)
+		if test.expectedErr == nil {
+			if err != nil {
+				t.Errorf("%d: expected no error, got %v", testNo+1, err)
+			}
+			continue
+		}
+
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%d: expected error %v, got %v", testNo+1, test.expectedErr, err)
+		}
+	}
+}
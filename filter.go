@@ -0,0 +1,30 @@
+package steamcmd
+
+// FilterByTag returns the outputs of AppInfoPrint from the given outputs that have the tag with the given ID, using
+// AppHasTag. The given outputs are keyed by app ID, like the outputs returned by BulkAppInfoPrint.
+func FilterByTag(outputs map[int64]map[string]any, tag string) map[int64]map[string]any {
+	return FilterByTags(outputs, []string{tag}, true)
+}
+
+// FilterByTags returns the outputs of AppInfoPrint from the given outputs that have the tags with the given IDs, using
+// AppHasTag. If requireAll is set, then an output must have all the given tags. Otherwise, an output must have at least
+// one of the given tags. The given outputs are keyed by app ID, like the outputs returned by BulkAppInfoPrint.
+func FilterByTags(outputs map[int64]map[string]any, tags []string, requireAll bool) map[int64]map[string]any {
+	filtered := make(map[int64]map[string]any)
+	for appID, output := range outputs {
+		if hasTags(output, tags, requireAll) {
+			filtered[appID] = output
+		}
+	}
+	return filtered
+}
+
+// hasTags checks whether the given output of AppInfoPrint has all (or any) of the given tags.
+func hasTags(output map[string]any, tags []string, requireAll bool) bool {
+	for _, tag := range tags {
+		if AppHasTag(output, tag) != requireAll {
+			return !requireAll
+		}
+	}
+	return requireAll
+}
@@ -0,0 +1,44 @@
+package steamcmd
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestFilterByTags(t *testing.T) {
+	outputs := map[int64]map[string]any{
+		477160: {"common": map[string]any{"store_tags": map[string]any{"0": "1664", "1": "4136", "2": "1685"}}},
+		620:    {"common": map[string]any{"store_tags": map[string]any{"0": "1664", "1": "122"}}},
+		730:    {"common": map[string]any{"store_tags": map[string]any{"0": "1663"}}},
+		440:    {"common": map[string]any{"name": "Team Fortress 2"}},
+	}
+
+	for testNo, test := range []struct {
+		tags       []string
+		requireAll bool
+		expected   []int64
+	}{
+		{[]string{"1664"}, true, []int64{620, 477160}},
+		{[]string{"1664", "122"}, true, []int64{620}},
+		{[]string{"122", "1663"}, false, []int64{620, 730}},
+		{[]string{"9999"}, false, []int64{}},
+		{[]string{}, true, []int64{440, 620, 730, 477160}},
+		{[]string{}, false, []int64{}},
+	} {
+		filtered := FilterByTags(outputs, test.tags, test.requireAll)
+		appIDs := make([]int64, 0, len(filtered))
+		for appID := range filtered {
+			appIDs = append(appIDs, appID)
+		}
+		sort.Slice(appIDs, func(i, j int) bool { return appIDs[i] < appIDs[j] })
+
+		if !reflect.DeepEqual(appIDs, test.expected) {
+			t.Errorf("%d: expected apps %v, got %v", testNo+1, test.expected, appIDs)
+		}
+	}
+
+	if filtered := FilterByTag(outputs, "1685"); len(filtered) != 1 || filtered[477160] == nil {
+		t.Errorf("expected only 477160 to have the tag 1685, got %v", filtered)
+	}
+}
@@ -3,14 +3,15 @@ module github.com/andygello555/go-steamcmd
 go 1.19
 
 require (
+	github.com/Netflix/go-expect v0.0.0-20220104043353-73e0943537d2
 	github.com/andygello555/agem v1.0.2
 	github.com/andygello555/url-fmt v1.0.0
 	github.com/hjson/hjson-go/v4 v4.3.0
 	github.com/pkg/errors v0.9.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
-	github.com/Netflix/go-expect v0.0.0-20220104043353-73e0943537d2 // indirect
 	github.com/anaskhan96/soup v1.2.5 // indirect
 	github.com/creack/pty v1.1.17 // indirect
 	golang.org/x/net v0.0.0-20200114155413-6afb5195e5aa // indirect
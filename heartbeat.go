@@ -0,0 +1,123 @@
+package steamcmd
+
+import (
+	"context"
+	"github.com/Netflix/go-expect"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// HeartbeatCommand is the harmless command that is sent to an interactive SteamCMD process by the heartbeat started by
+// WithHeartbeat.
+const HeartbeatCommand = "info"
+
+// sessionStateCommands are the CommandType of the Command that change the state of the SteamCMD process, rather than
+// just querying it. These are replayed by reconnect so that the restarted process is in the same state as the old one.
+var sessionStateCommands = map[CommandType]bool{
+	Login:           true,
+	ForceInstallDir: true,
+	AppInfoUpdate:   true,
+}
+
+// startHeartbeat will start the heartbeat goroutine that sends the HeartbeatCommand every heartbeatInterval.
+func (sc *SteamCMD) startHeartbeat() {
+	var ctx context.Context
+	ctx, sc.heartbeatCancel = context.WithCancel(context.Background())
+	sc.heartbeatErr = nil
+	sc.heartbeatWg.Add(1)
+	go sc.heartbeat(ctx)
+}
+
+// stopHeartbeat will stop the heartbeat goroutine, if it is running, and wait for it to finish. The error that caused
+// the heartbeat to stop early (if any) is returned.
+func (sc *SteamCMD) stopHeartbeat() error {
+	if sc.heartbeatCancel != nil {
+		sc.heartbeatCancel()
+		sc.heartbeatWg.Wait()
+		sc.heartbeatCancel = nil
+	}
+	return sc.heartbeatErr
+}
+
+// heartbeat sends the HeartbeatCommand every heartbeatInterval until the given context is cancelled. If a heartbeat
+// fails, then SteamCMD will reconnect. If the reconnect fails, then the heartbeat will stop and the error will be
+// returned by stopHeartbeat.
+func (sc *SteamCMD) heartbeat(ctx context.Context) {
+	defer sc.heartbeatWg.Done()
+	ticker := time.NewTicker(sc.heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			sc.consoleMu.Lock()
+			if err := sc.sendHeartbeat(); err != nil {
+				if err = sc.reconnect(); err != nil {
+					sc.heartbeatErr = errors.Wrap(err, "heartbeat stopped")
+					sc.consoleMu.Unlock()
+					return
+				}
+			}
+			sc.consoleMu.Unlock()
+		}
+	}
+}
+
+// sendHeartbeat sends the HeartbeatCommand to the SteamCMD process and waits for the InteractivePrompt for up to the
+// timeout given to WithHeartbeatTimeout, or ExpectTimeout. The caller must hold consoleMu.
+func (sc *SteamCMD) sendHeartbeat() (err error) {
+	if sc.console == nil {
+		return errors.New("cannot send heartbeat when the SteamCMD process is not running")
+	}
+
+	if _, err = sc.console.SendLine(HeartbeatCommand); err != nil {
+		return errors.Wrap(err, "could not send heartbeat")
+	}
+
+	timeout := sc.heartbeatTimeout
+	if timeout <= 0 {
+		timeout = ExpectTimeout
+	}
+
+	if _, err = sc.console.Expect(sc.expectPrompt(), expect.WithTimeout(timeout)); err != nil {
+		return errors.Wrap(err, "could not expect SteamCMD prompt after heartbeat")
+	}
+	return
+}
+
+// reconnect will kill the current SteamCMD process, then start a new one that is logged in. Any Command that changes
+// the state of the process (see sessionStateCommands) that has been queued/executed is replayed, in order, when the new
+// process is started. This is used when the interactive SteamCMD process has stalled. The caller must hold consoleMu.
+func (sc *SteamCMD) reconnect() (err error) {
+	sc.publish(EventReconnecting, nil)
+	// The process has stalled, so there is no point in waiting for it to quit gracefully. We also ignore any errors from
+	// killing the process or closing the console, as the process might have already exited. The console is closed before
+	// waiting so that the goroutines copying the process' output cannot block on writing to it. Cmd.Wait is used so that
+	// these goroutines have finished before the redactWriter(s) are flushed.
+	if sc.cmd != nil && sc.cmd.Process != nil {
+		_ = sc.cmd.Process.Kill()
+	}
+
+	if sc.console != nil {
+		_ = sc.console.Close()
+	}
+
+	if sc.cmd != nil && sc.cmd.Process != nil {
+		_ = sc.cmd.Wait()
+	}
+	_ = sc.flushOutputWriters()
+
+	args := append(append([]string{}, sc.directives...), sc.serialisedCommands[0])
+	for i, command := range sc.commands {
+		if sessionStateCommands[command.Type] {
+			// The first serialised command is the login, so each Command is one ahead in serialisedCommands
+			args = append(args, sc.serialisedCommands[i+1])
+		}
+	}
+	if err = sc.startProcess(args); err != nil {
+		err = errors.Wrap(err, "could not reconnect interactive SteamCMD")
+	}
+	return
+}
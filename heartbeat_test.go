@@ -0,0 +1,149 @@
+package steamcmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithHeartbeat(t *testing.T) {
+	for testNo, test := range []struct {
+		stallOnStart       int
+		expectedReconnects int
+	}{
+		{0, 0},
+		{1, 1},
+		{2, 2},
+	} {
+		func() {
+			// The fake steamcmd logs the args it was started with, and stalls on the heartbeat when it is the
+			// stallOnStart-th process to be started
+			log := filepath.Join(t.TempDir(), "starts.log")
+			fakeSteamCMD(t, fmt.Sprintf(`echo "$@" >> %q
+n=$(wc -l < %q)
+printf 'Steam>'
+while IFS= read -r line; do
+	case "$line" in
+	quit) exit 0 ;;
+	%s) [ "$n" -le %d ] && exec sleep 30 ;;
+	esac
+	printf 'Steam>'
+done`, log, log, HeartbeatCommand, test.stallOnStart))
+
+			sc := NewWithCredentials(
+				"bob", "hunter2", "", true,
+				WithHeartbeat(time.Millisecond*20), WithHeartbeatTimeout(time.Millisecond*200),
+			)
+			reconnecting := sc.Subscribe(EventReconnecting)
+			start := time.Now()
+			if err := sc.Start(); err != nil {
+				t.Fatalf("%d: could not start SteamCMD: %v", testNo+1, err)
+			}
+
+			// Wait for the expected number of reconnects, and then a few more heartbeats to make sure that there are
+			// no more reconnects
+			for i := 0; i < test.expectedReconnects; i++ {
+				select {
+				case <-reconnecting:
+				case <-time.After(time.Second * 5):
+					t.Fatalf("%d: timed out waiting for reconnect no. %d", testNo+1, i+1)
+				}
+
+				// We should only reconnect once the heartbeat has timed out
+				if elapsed := time.Since(start); elapsed < time.Millisecond*200*time.Duration(i+1) {
+					t.Errorf("%d: expected reconnect no. %d after the heartbeat timed out, took %s", testNo+1, i+1, elapsed.String())
+				}
+			}
+			time.Sleep(time.Millisecond * 100)
+
+			if err := sc.Close(); err != nil {
+				t.Errorf("%d: unexpected error whilst closing: %v", testNo+1, err)
+			}
+
+			if len(reconnecting) != 0 {
+				t.Errorf("%d: expected %d reconnects, got %d more", testNo+1, test.expectedReconnects, len(reconnecting))
+			}
+
+			// Each restarted process should be logged back in
+			b, err := os.ReadFile(log)
+			if err != nil {
+				t.Fatalf("%d: could not read log of fake steamcmd: %v", testNo+1, err)
+			}
+
+			expected := make([]string, test.expectedReconnects+1)
+			for i := range expected {
+				expected[i] = "+login bob hunter2"
+			}
+			if starts := strings.Split(strings.TrimSpace(string(b)), "\n"); !reflect.DeepEqual(starts, expected) {
+				t.Errorf("%d: expected steamcmd to be started with %q, got %q", testNo+1, expected, starts)
+			}
+		}()
+	}
+}
+
+func TestWithHeartbeat_replaysSessionState(t *testing.T) {
+	// The fake steamcmd logs the args it was started with, and stalls on the heartbeat once the stall file exists and
+	// it is the first process to be started
+	dir := t.TempDir()
+	log, stall := filepath.Join(dir, "starts.log"), filepath.Join(dir, "stall")
+	fakeSteamCMD(t, fmt.Sprintf(`echo "$@" >> %q
+n=$(wc -l < %q)
+printf 'Steam>'
+while IFS= read -r line; do
+	case "$line" in
+	quit) exit 0 ;;
+	login*) printf 'Logging in user...\nLogged in OK\n' ;;
+	app_info_print*) cat <<'EOF'
+%s
+EOF
+;;
+	%s) [ "$n" -le 1 ] && [ -f %q ] && exec sleep 30 ;;
+	esac
+	printf 'Steam>'
+done`, log, log, sampleAppInfoPrintOutput, HeartbeatCommand, stall))
+
+	sc := New(true, WithHeartbeat(time.Millisecond*20), WithHeartbeatTimeout(time.Millisecond*200))
+	reconnecting := sc.Subscribe(EventReconnecting)
+	if err := sc.Start(); err != nil {
+		t.Fatalf("could not start SteamCMD: %v", err)
+	}
+
+	for _, command := range []*CommandWithArgs{
+		NewCommandWithArgs(ForceInstallDir, "/games"),
+		NewCommandWithArgs(AppInfoPrint, 477160),
+		NewCommandWithArgs(Login, "bob", "hunter2"),
+	} {
+		if err := sc.AddCommand(command.Command, command.Args...); err != nil {
+			t.Fatalf("could not queue/execute %s: %v", command.Command.Type.String(), err)
+		}
+	}
+
+	if err := os.WriteFile(stall, nil, 0o644); err != nil {
+		t.Fatalf("could not create stall file: %v", err)
+	}
+
+	select {
+	case <-reconnecting:
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for reconnect")
+	}
+
+	if err := sc.Close(); err != nil {
+		t.Errorf("unexpected error whilst closing: %v", err)
+	}
+
+	b, err := os.ReadFile(log)
+	if err != nil {
+		t.Fatalf("could not read log of fake steamcmd: %v", err)
+	}
+
+	// The AppInfoPrint command only queries the process, so it should not be replayed
+	expected := []string{"+login anonymous", "+login anonymous +force_install_dir /games +login bob hunter2"}
+	if starts := strings.Split(strings.TrimSpace(string(b)), "\n"); !reflect.DeepEqual(starts, expected) {
+		t.Errorf("expected steamcmd to be started with %q, got %q", expected, starts)
+	}
+}
@@ -0,0 +1,2692 @@
+package steamcmd
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"html"
+	"math"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrFieldNotFound is returned by the helpers for the parsed output of AppInfoPrint when the field that is being looked
+// up does not exist.
+var ErrFieldNotFound = errors.New("field not found")
+
+// ErrPathConflict is returned by SetNestedValue when an intermediate key within the path exists, but is not a map.
+var ErrPathConflict = errors.New("path conflicts with an existing value")
+
+// GetNestedValue will return the value at the given dot-separated path within the given output map. I.e. the path
+// "common.name" will return the value of the "name" key within the "common" map. If any of the keys within the path
+// do not exist, or an intermediate value is not a map, then ErrFieldNotFound will be returned.
+func GetNestedValue(output map[string]any, path string) (value any, err error) {
+	current := output
+	keys := strings.Split(path, ".")
+	for i, key := range keys {
+		var ok bool
+		if value, ok = current[key]; !ok {
+			return nil, errors.Wrapf(ErrFieldNotFound, "cannot find %q in %q", key, path)
+		}
+
+		if i < len(keys)-1 {
+			if current, ok = value.(map[string]any); !ok {
+				return nil, errors.Wrapf(ErrFieldNotFound, "%q in %q is not a map", key, path)
+			}
+		}
+	}
+	return
+}
+
+// SetNestedValue will set the value at the given dot-separated path within the given output map. This is the
+// complement to GetNestedValue. Any intermediate maps within the path that do not exist will be created. If an
+// intermediate key exists but its value is not a map, then ErrPathConflict will be returned. An error is also returned
+// if the output map, or any intermediate map, is nil, as a value cannot be set within a nil map.
+func SetNestedValue(output map[string]any, path string, value any) error {
+	if output == nil {
+		return errors.Errorf("cannot set %q within a nil map", path)
+	}
+
+	current := output
+	keys := strings.Split(path, ".")
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key]
+		if !ok {
+			next = make(map[string]any)
+			current[key] = next
+		}
+
+		if current, ok = next.(map[string]any); !ok {
+			return errors.Wrapf(ErrPathConflict, "%q in %q is not a map, it is a %T", key, path, next)
+		}
+
+		if current == nil {
+			return errors.Errorf("cannot set %q as %q is a nil map", path, key)
+		}
+	}
+	current[keys[len(keys)-1]] = value
+	return nil
+}
+
+// AppID extracts the top-level "appid" field from the output of AppInfoPrint. If the field cannot be found then
+// ErrFieldNotFound is returned.
+func AppID(output map[string]any) (int64, error) {
+	return getNestedInt64(output, "appid")
+}
+
+// AppName extracts the "name" field from the "common" section of the output of AppInfoPrint. If the field cannot be
+// found then ErrFieldNotFound is returned.
+func AppName(output map[string]any) (string, error) {
+	return getNestedString(output, "common.name")
+}
+
+// AppType extracts the "type" field from the "common" section of the output of AppInfoPrint. I.e. "Game", "DLC". If the
+// field cannot be found then ErrFieldNotFound is returned.
+func AppType(output map[string]any) (string, error) {
+	return getNestedString(output, "common.type")
+}
+
+// AppParentID extracts the "dlcforappid" field from the "extended" section of the output of AppInfoPrint. This is the
+// app ID of the parent game of a DLC. If the field cannot be found, i.e. the app is not a DLC, then ErrFieldNotFound is
+// returned.
+func AppParentID(output map[string]any) (int64, error) {
+	return getNestedInt64(output, "extended.dlcforappid")
+}
+
+// AppIsDLC checks whether the output of AppInfoPrint has a non-zero parent app ID using AppParentID.
+func AppIsDLC(output map[string]any) bool {
+	parentID, err := AppParentID(output)
+	return err == nil && parentID != 0
+}
+
+// AppIsDLCFor checks whether the output of AppInfoPrint is a DLC for the app with the given ID using AppParentID.
+func AppIsDLCFor(output map[string]any, parentAppID int64) bool {
+	parentID, err := AppParentID(output)
+	return err == nil && parentID != 0 && parentID == parentAppID
+}
+
+// AppDemoOfAppID returns the ID of the full app that the output of AppInfoPrint is a demo of. This is located in the
+// "demo_of_appid" field in the "extended" section.
+func AppDemoOfAppID(output map[string]any) (int64, error) {
+	return getNestedInt64(output, "extended.demo_of_appid")
+}
+
+// AppIsDemo checks whether the output of AppInfoPrint has a non-zero full app ID using AppDemoOfAppID.
+func AppIsDemo(output map[string]any) bool {
+	fullAppID, err := AppDemoOfAppID(output)
+	return err == nil && fullAppID != 0
+}
+
+// AppIsDemoFor checks whether the output of AppInfoPrint is a demo of the app with the given ID using AppDemoOfAppID.
+func AppIsDemoFor(output map[string]any, parentAppID int64) bool {
+	fullAppID, err := AppDemoOfAppID(output)
+	return err == nil && fullAppID != 0 && fullAppID == parentAppID
+}
+
+// The types of DLC that are returned by AppDLCType.
+const (
+	DLCTypeRequired = "required"
+	DLCTypeOptional = "optional"
+)
+
+// appIDList extracts the comma-separated list of app IDs at the given path of the output of AppInfoPrint.
+func appIDList(output map[string]any, path string) ([]int64, error) {
+	list, err := getNestedString(output, path)
+	if err != nil {
+		return nil, err
+	}
+
+	elements := splitList(list)
+	appIDs := make([]int64, len(elements))
+	for i, element := range elements {
+		if appIDs[i], err = toInt64(element); err != nil {
+			return nil, errors.Wrapf(err, "could not parse app ID %q in %s", element, path)
+		}
+	}
+	return appIDs, nil
+}
+
+// AppOptionalDLC extracts the comma-separated "optional_dlc" list of app IDs from the "extended" section of the output
+// of AppInfoPrint. These are the DLCs that are not required to play the app. If the field cannot be found then
+// ErrFieldNotFound is returned.
+func AppOptionalDLC(output map[string]any) ([]int64, error) {
+	return appIDList(output, "extended.optional_dlc")
+}
+
+// AppDLCType returns whether the DLC with the given app ID is in the "optional_dlc" list (DLCTypeOptional), or the
+// "listofdlc" list (DLCTypeRequired), within the "extended" section of the output of AppInfoPrint. As "listofdlc" can
+// also contain optional DLCs, "optional_dlc" is checked first. If the DLC cannot be found in either list then
+// ErrFieldNotFound is returned.
+func AppDLCType(output map[string]any, dlcAppID int64) (string, error) {
+	for _, list := range []struct {
+		path    string
+		dlcType string
+	}{
+		{"extended.optional_dlc", DLCTypeOptional},
+		{"extended.listofdlc", DLCTypeRequired},
+	} {
+		appIDs, err := appIDList(output, list.path)
+		if err != nil && !errors.Is(err, ErrFieldNotFound) {
+			return "", err
+		}
+
+		for _, appID := range appIDs {
+			if appID == dlcAppID {
+				return list.dlcType, nil
+			}
+		}
+	}
+	return "", errors.Wrapf(ErrFieldNotFound, "cannot find DLC %d in optional_dlc or listofdlc", dlcAppID)
+}
+
+// getNestedString will return the value at the given path using GetNestedValue, then convert it to a string.
+func getNestedString(output map[string]any, path string) (string, error) {
+	value, err := GetNestedValue(output, path)
+	if err != nil {
+		return "", err
+	}
+	return toString(value), nil
+}
+
+// getNestedInt64 will return the value at the given path using GetNestedValue, then convert it to an int64 using
+// toInt64.
+func getNestedInt64(output map[string]any, path string) (int64, error) {
+	value, err := GetNestedValue(output, path)
+	if err != nil {
+		return 0, err
+	}
+	return toInt64(value)
+}
+
+// toString converts the given value from the parsed output of AppInfoPrint to a string. Strings are trimmed of any
+// surrounding whitespace that might have been left over from parsing.
+func toString(value any) string {
+	switch value.(type) {
+	case string:
+		return strings.TrimSpace(value.(string))
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// toInt64 converts the given value from the parsed output of AppInfoPrint to an int64. As most values in the parsed
+// output of AppInfoPrint are strings, strings will be parsed as integers.
+func toInt64(value any) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint:
+		return int64(v), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case float32:
+		return int64(v), nil
+	case float64:
+		return int64(v), nil
+	case string:
+		i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return 0, errors.Wrapf(err, "cannot convert %q to an integer", v)
+		}
+		return i, nil
+	default:
+		return 0, errors.Errorf("cannot convert %v (type: %T) to an integer", value, value)
+	}
+}
+
+// ReviewScore represents the Steam review score scale that is used for the "review_score" field in the output of
+// AppInfoPrint.
+type ReviewScore int
+
+const (
+	// ReviewOverwhelminglyNegative is for apps with 500+ reviews, of which 0-19% are positive.
+	ReviewOverwhelminglyNegative ReviewScore = iota + 1
+	// ReviewVeryNegative is for apps with 50-499 reviews, of which 0-19% are positive.
+	ReviewVeryNegative
+	// ReviewNegative is for apps with 10-49 reviews, of which 0-19% are positive.
+	ReviewNegative
+	// ReviewMostlyNegative is for apps with 20-39% positive reviews.
+	ReviewMostlyNegative
+	// ReviewMixed is for apps with 40-69% positive reviews.
+	ReviewMixed
+	// ReviewMostlyPositive is for apps with 70-79% positive reviews.
+	ReviewMostlyPositive
+	// ReviewPositive is for apps with 10-49 reviews, of which 80-100% are positive.
+	ReviewPositive
+	// ReviewVeryPositive is for apps with 50-499 reviews, of which 80-100% are positive.
+	ReviewVeryPositive
+	// ReviewOverwhelminglyPositive is for apps with 500+ reviews, of which 95-100% are positive.
+	ReviewOverwhelminglyPositive
+)
+
+// String returns the description of the ReviewScore that is displayed on the Steam store.
+func (rs ReviewScore) String() string {
+	switch rs {
+	case ReviewOverwhelminglyNegative:
+		return "Overwhelmingly Negative"
+	case ReviewVeryNegative:
+		return "Very Negative"
+	case ReviewNegative:
+		return "Negative"
+	case ReviewMostlyNegative:
+		return "Mostly Negative"
+	case ReviewMixed:
+		return "Mixed"
+	case ReviewMostlyPositive:
+		return "Mostly Positive"
+	case ReviewPositive:
+		return "Positive"
+	case ReviewVeryPositive:
+		return "Very Positive"
+	case ReviewOverwhelminglyPositive:
+		return "Overwhelmingly Positive"
+	default:
+		return "<nil>"
+	}
+}
+
+// AppReviewScore extracts the "review_score" and "review_score_desc" fields from the "common" section of the output of
+// AppInfoPrint. The score can be compared against the ReviewScore constants. If either of the fields cannot be found
+// then ErrFieldNotFound is returned.
+func AppReviewScore(output map[string]any) (score int, desc string, err error) {
+	var score64 int64
+	if _, err = GetNestedValue(output, "common.review_score"); err != nil {
+		return 0, "", err
+	}
+
+	if desc, err = getNestedString(output, "common.review_score_desc"); err != nil {
+		return 0, "", err
+	}
+
+	if score64, err = getNestedInt64(output, "common.review_score"); err != nil {
+		return 0, "", errors.Wrap(err, "could not parse review_score")
+	}
+	return int(score64), desc, nil
+}
+
+// AppReviewCounts extracts the "total_positive" and "total_negative" review counts, as well as the
+// "review_percentage", from the "common" section of the output of AppInfoPrint. If the percentage cannot be found, then
+// it is calculated from the review counts.
+//
+// If the review counts cannot be found then ErrFieldNotFound is returned, but the percentage will still be returned if
+// it can be found. This is the case for apps that only have a review score category (see AppReviewScore).
+func AppReviewCounts(output map[string]any) (positive, negative int64, pct float64, err error) {
+	pctErr := ErrFieldNotFound
+	if value, valueErr := GetNestedValue(output, "common.review_percentage"); valueErr == nil {
+		if pct, pctErr = strconv.ParseFloat(toString(value), 64); pctErr != nil {
+			return 0, 0, 0, errors.Wrap(pctErr, "could not parse review_percentage")
+		}
+	}
+
+	if positive, err = getNestedInt64(output, "common.total_positive"); err != nil {
+		return 0, 0, pct, errors.Wrap(err, "could not find total_positive")
+	}
+
+	if negative, err = getNestedInt64(output, "common.total_negative"); err != nil {
+		return 0, 0, pct, errors.Wrap(err, "could not find total_negative")
+	}
+
+	if pctErr != nil && positive+negative > 0 {
+		pct = float64(positive) / float64(positive+negative) * 100
+	}
+	return
+}
+
+// AppAllTimePositiveReviews extracts the all-time "total_positive" review count from the "common" section of the output
+// of AppInfoPrint. If the field cannot be found then ErrFieldNotFound is returned.
+func AppAllTimePositiveReviews(output map[string]any) (int64, error) {
+	return getNestedInt64(output, "common.total_positive")
+}
+
+// AppAllTimeNegativeReviews is the same as AppAllTimePositiveReviews, but the "total_negative" review count is
+// extracted instead.
+func AppAllTimeNegativeReviews(output map[string]any) (int64, error) {
+	return getNestedInt64(output, "common.total_negative")
+}
+
+// AppReviewRatio returns the ratio of AppAllTimePositiveReviews to the total number of all-time reviews, between 0 and
+// 1. If either of the review counts cannot be found, or the app has no reviews, then ErrFieldNotFound is returned.
+func AppReviewRatio(output map[string]any) (float64, error) {
+	positive, err := AppAllTimePositiveReviews(output)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not find total_positive")
+	}
+
+	var negative int64
+	if negative, err = AppAllTimeNegativeReviews(output); err != nil {
+		return 0, errors.Wrap(err, "could not find total_negative")
+	}
+
+	if positive+negative <= 0 {
+		return 0, errors.Wrap(ErrFieldNotFound, "app has no reviews")
+	}
+	return float64(positive) / float64(positive+negative), nil
+}
+
+// AppMetacritic extracts the "metacritic_score" and "metacritic_fullurl" fields from the "common" section of the output
+// of AppInfoPrint. If either of the fields cannot be found then ErrFieldNotFound is returned.
+func AppMetacritic(output map[string]any) (score int, url string, err error) {
+	var score64 int64
+	if _, err = GetNestedValue(output, "common.metacritic_score"); err != nil {
+		return 0, "", err
+	}
+
+	if url, err = getNestedString(output, "common.metacritic_fullurl"); err != nil {
+		return 0, "", err
+	}
+
+	if score64, err = getNestedInt64(output, "common.metacritic_score"); err != nil {
+		return 0, "", errors.Wrap(err, "could not parse metacritic_score")
+	}
+	return int(score64), url, nil
+}
+
+// AppHasMetacritic checks whether the output of AppInfoPrint has both the "metacritic_score" and "metacritic_fullurl"
+// fields.
+func AppHasMetacritic(output map[string]any) bool {
+	_, _, err := AppMetacritic(output)
+	return err == nil
+}
+
+// AppReleaseDateWithConfidence extracts the raw release date string from the "common" section of the output of
+// AppInfoPrint, and parses it using ParseSteamDatePrecision. The raw release date is read from "release_date"."date",
+// or "release_date" if it is not nested. The returned DatePrecision can be used to display imprecise release dates
+// appropriately, i.e. "~Q2 2021".
+func AppReleaseDateWithConfidence(output map[string]any) (date time.Time, precision DatePrecision, raw string, err error) {
+	if raw, err = getNestedString(output, "common.release_date.date"); err != nil {
+		var value any
+		if value, err = GetNestedValue(output, "common.release_date"); err != nil {
+			return
+		}
+
+		if _, ok := value.(map[string]any); ok {
+			err = errors.Wrap(ErrFieldNotFound, "release_date does not contain a date")
+			return
+		}
+		raw = toString(value)
+	}
+
+	if date, precision, err = ParseSteamDatePrecision(raw); err != nil {
+		err = errors.Wrapf(err, "could not parse release date %q", raw)
+	}
+	return
+}
+
+// AppSteamReleaseDate extracts the "steam_release_date" Unix timestamp from the "common" section of the output of
+// AppInfoPrint. This is the date that the app was released on Steam. If the field cannot be found then ErrFieldNotFound
+// is returned.
+func AppSteamReleaseDate(output map[string]any) (time.Time, error) {
+	date, err := getNestedString(output, "common.steam_release_date")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var releaseDate time.Time
+	if releaseDate, err = SteamDateLayout(UnixTimestamp).Parse(date); err != nil {
+		return time.Time{}, errors.Wrapf(err, "could not parse steam_release_date %q", date)
+	}
+	return releaseDate, nil
+}
+
+// AppOriginalReleaseDate extracts the "original_release_date" from the "common" section of the output of AppInfoPrint,
+// and parses it using ParseSteamDate. This is the date that the app was first released, which might have been on
+// another platform, so it can differ from AppSteamReleaseDate. If the field cannot be found then ErrFieldNotFound is
+// returned.
+func AppOriginalReleaseDate(output map[string]any) (time.Time, error) {
+	date, err := getNestedString(output, "common.original_release_date")
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var releaseDate time.Time
+	if releaseDate, err = ParseSteamDate(date); err != nil {
+		return time.Time{}, errors.Wrapf(err, "could not parse original_release_date %q", date)
+	}
+	return releaseDate, nil
+}
+
+// splitList splits the given comma-separated list from the output of AppInfoPrint, trimming each element and removing
+// any empty elements.
+func splitList(list string) []string {
+	elements := make([]string, 0)
+	for _, element := range strings.Split(list, ",") {
+		if element = strings.TrimSpace(element); element != "" {
+			elements = append(elements, element)
+		}
+	}
+	return elements
+}
+
+// AppOSList extracts the comma-separated "oslist" field from the "common" section of the output of AppInfoPrint. I.e.
+// "windows,macos,linux" will be returned as []string{"windows", "macos", "linux"}.
+func AppOSList(output map[string]any) ([]string, error) {
+	oslist, err := getNestedString(output, "common.oslist")
+	if err != nil {
+		return nil, err
+	}
+	return splitList(oslist), nil
+}
+
+// steamOSToGOOS maps the OS names used by Steam to their runtime.GOOS equivalents.
+var steamOSToGOOS = map[string]string{
+	"windows": "windows",
+	"macos":   "darwin",
+	"linux":   "linux",
+}
+
+// AppGOOS returns the OS from AppOSList as their runtime.GOOS equivalents. I.e. "macos" will be returned as "darwin".
+// Any OS that is not recognised will be skipped.
+func AppGOOS(output map[string]any) []string {
+	goos := make([]string, 0)
+	oslist, _ := AppOSList(output)
+	for _, os := range oslist {
+		if g, ok := steamOSToGOOS[strings.ToLower(os)]; ok {
+			goos = append(goos, g)
+		}
+	}
+	return goos
+}
+
+// GOOSToSteamOS maps the given runtime.GOOS to the OS name that is used by Steam. This is the inverse of the mapping
+// used in AppGOOS.
+func GOOSToSteamOS(goos string) (string, error) {
+	for steamOS, g := range steamOSToGOOS {
+		if g == goos {
+			return steamOS, nil
+		}
+	}
+	return "", errors.Errorf("GOOS %q is not supported by Steam", goos)
+}
+
+// AppExtendedBoolField extracts the field with the given key from the "extended" section of the output of AppInfoPrint,
+// and converts it to a bool. The steamcmd object format stores bools as "0" and "1", but any value accepted by
+// strconv.ParseBool can be converted. If the field cannot be found then ErrFieldNotFound is returned.
+func AppExtendedBoolField(output map[string]any, key string) (bool, error) {
+	value, err := getNestedString(output, "extended."+key)
+	if err != nil {
+		return false, err
+	}
+
+	var b bool
+	if b, err = strconv.ParseBool(value); err != nil {
+		return false, errors.Wrapf(err, "cannot convert extended.%s to a bool", key)
+	}
+	return b, nil
+}
+
+// AppIsFree checks whether the "isfreeapp" field within the "extended" section of the output of AppInfoPrint is set.
+// False is returned if the field cannot be found.
+func AppIsFree(output map[string]any) bool {
+	isFree, err := AppExtendedBoolField(output, "isfreeapp")
+	return err == nil && isFree
+}
+
+// AppExcludedFromFamilySharing checks whether the "exfgls" field within the "extended" section of the output of
+// AppInfoPrint is set. This means that the app is excluded from Steam Family Library Sharing. False is returned if the
+// field cannot be found.
+func AppExcludedFromFamilySharing(output map[string]any) bool {
+	excluded, err := AppExtendedBoolField(output, "exfgls")
+	return err == nil && excluded
+}
+
+// achievementStatType is the "type" of an achievement within the "stats" section of the output of AppInfoPrint.
+const achievementStatType = "4"
+
+// achievementCountPaths are the paths that the old format "achievement_count" field can be found at.
+var achievementCountPaths = []string{"common.achievement_count", "achievement_count"}
+
+// AppAchievementCount counts the achievements of the app from the output of AppInfoPrint. The achievements are counted
+// from the "stats" section, which is a map of stat ID to stat, where achievements have a "type" of "4". If the "stats"
+// section cannot be found, then the old format "achievement_count" field is read from the "common" section or the root
+// of the output instead. If neither can be found then ErrFieldNotFound is returned.
+func AppAchievementCount(output map[string]any) (int, error) {
+	if value, err := GetNestedValue(output, "stats"); err == nil {
+		stats, ok := value.(map[string]any)
+		if !ok {
+			return 0, errors.Errorf("stats is not a map, it is a %T", value)
+		}
+
+		count := 0
+		for _, statValue := range stats {
+			if stat, ok := statValue.(map[string]any); ok && toString(stat["type"]) == achievementStatType {
+				count++
+			}
+		}
+		return count, nil
+	}
+
+	for _, path := range achievementCountPaths {
+		if _, err := GetNestedValue(output, path); err != nil {
+			continue
+		}
+
+		count, err := getNestedInt64(output, path)
+		if err != nil {
+			return 0, errors.Wrapf(err, "could not parse %s", path)
+		}
+		return int(count), nil
+	}
+	return 0, errors.Wrap(ErrFieldNotFound, "cannot find a stats section or an achievement_count")
+}
+
+// AppHasAchievements checks whether the output of AppInfoPrint has at least one achievement using
+// AppAchievementCount.
+func AppHasAchievements(output map[string]any) bool {
+	count, err := AppAchievementCount(output)
+	return err == nil && count > 0
+}
+
+// Badge is a Steam badge that can be earned for an app.
+type Badge struct {
+	Level    int
+	Name     string
+	ImageURL string
+}
+
+// steamBadgesPaths are the paths that the "steam_badges" section can be found at.
+var steamBadgesPaths = []string{"steam_badges", "common.steam_badges"}
+
+// AppSteamBadges extracts the "steam_badges" section from the output of AppInfoPrint. The root of the output, as well as
+// the "common" section are checked for the section. Each entry in the section is a map containing a "level", a "name",
+// and an "image" (or "image_url"). If an entry does not have a "level", then the key of the entry is used as the level.
+// The badges are returned sorted by their level. If the section cannot be found then ErrFieldNotFound is returned.
+func AppSteamBadges(output map[string]any) ([]Badge, error) {
+	for _, path := range steamBadgesPaths {
+		value, err := GetNestedValue(output, path)
+		if err != nil {
+			continue
+		}
+
+		badgesMap, ok := value.(map[string]any)
+		if !ok {
+			return nil, errors.Errorf("%s is not a map, it is a %T", path, value)
+		}
+
+		badges := make([]Badge, 0, len(badgesMap))
+		for key, badgeValue := range badgesMap {
+			badgeMap, ok := badgeValue.(map[string]any)
+			if !ok {
+				return nil, errors.Errorf("badge %s is not a map, it is a %T", key, badgeValue)
+			}
+
+			level, ok := badgeMap["level"]
+			if !ok {
+				level = key
+			}
+
+			var (
+				badge   Badge
+				level64 int64
+			)
+			if level64, err = toInt64(level); err != nil {
+				return nil, errors.Wrapf(err, "could not parse level of badge %s", key)
+			}
+			badge.Level = int(level64)
+
+			if name, ok := badgeMap["name"]; ok {
+				badge.Name = toString(name)
+			}
+
+			for _, imageKey := range []string{"image", "image_url"} {
+				if image, ok := badgeMap[imageKey]; ok {
+					badge.ImageURL = toString(image)
+					break
+				}
+			}
+			badges = append(badges, badge)
+		}
+
+		sort.Slice(badges, func(i, j int) bool {
+			return badges[i].Level < badges[j].Level
+		})
+		return badges, nil
+	}
+	return nil, errors.Wrap(ErrFieldNotFound, "cannot find a steam_badges section")
+}
+
+// AppMaxBadgeLevel returns the highest Badge.Level of the badges returned by AppSteamBadges. If the app has no badges
+// then ErrFieldNotFound is returned.
+func AppMaxBadgeLevel(output map[string]any) (int, error) {
+	badges, err := AppSteamBadges(output)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(badges) == 0 {
+		return 0, errors.Wrap(ErrFieldNotFound, "steam_badges section is empty")
+	}
+	return badges[len(badges)-1].Level, nil
+}
+
+// AppHasBadges checks whether the output of AppInfoPrint has at least one badge using AppSteamBadges.
+func AppHasBadges(output map[string]any) bool {
+	badges, err := AppSteamBadges(output)
+	return err == nil && len(badges) > 0
+}
+
+// EULA is an end-user license agreement that must be accepted before an app can be installed or played.
+type EULA struct {
+	ID   string
+	Name string
+	URL  string
+}
+
+// eulasPaths are the paths that the "eulas" section can be found at.
+var eulasPaths = []string{"extended.eulas", "common.eulas"}
+
+// AppEULAs extracts the "eulas" section from the "extended" (or "common") section of the output of AppInfoPrint. This
+// section is a map of index to EULA, where each EULA is a map containing an "id", a "name", and a "url". The EULAs are
+// returned in the order of their index. If the section cannot be found then ErrFieldNotFound is returned.
+func AppEULAs(output map[string]any) ([]EULA, error) {
+	for _, path := range eulasPaths {
+		value, err := GetNestedValue(output, path)
+		if err != nil {
+			continue
+		}
+
+		eulasMap, ok := value.(map[string]any)
+		if !ok {
+			return nil, errors.Errorf("%s is not a map, it is a %T", path, value)
+		}
+
+		indices := make([]string, 0, len(eulasMap))
+		for index := range eulasMap {
+			indices = append(indices, index)
+		}
+		sort.Slice(indices, func(i, j int) bool {
+			return lessNumeric(indices[i], indices[j])
+		})
+
+		eulas := make([]EULA, 0, len(eulasMap))
+		for _, index := range indices {
+			eulaMap, ok := eulasMap[index].(map[string]any)
+			if !ok {
+				return nil, errors.Errorf("EULA at index %s is not a map, it is a %T", index, eulasMap[index])
+			}
+
+			var eula EULA
+			for key, field := range map[string]*string{"id": &eula.ID, "name": &eula.Name, "url": &eula.URL} {
+				if fieldValue, ok := eulaMap[key]; ok {
+					*field = toString(fieldValue)
+				}
+			}
+			eulas = append(eulas, eula)
+		}
+		return eulas, nil
+	}
+	return nil, errors.Wrap(ErrFieldNotFound, "cannot find a eulas section")
+}
+
+// AppRequiresEULA checks whether the output of AppInfoPrint has at least one EULA using AppEULAs.
+func AppRequiresEULA(output map[string]any) bool {
+	eulas, err := AppEULAs(output)
+	return err == nil && len(eulas) > 0
+}
+
+// openVRPaths are the paths that the "openvr" section can be found at.
+var openVRPaths = []string{"openvr", "common.openvr"}
+
+// appOpenVR returns the "openvr" section of the output of AppInfoPrint, and the path that it was found at.
+func appOpenVR(output map[string]any) (openVR map[string]any, path string, err error) {
+	for _, path = range openVRPaths {
+		var value any
+		if value, err = GetNestedValue(output, path); err != nil {
+			continue
+		}
+
+		var ok bool
+		if openVR, ok = value.(map[string]any); !ok {
+			return nil, path, errors.Errorf("%s is not a map, it is a %T", path, value)
+		}
+		return openVR, path, nil
+	}
+	return nil, "", errors.Wrap(ErrFieldNotFound, "cannot find an openvr section")
+}
+
+// AppOpenVRControllerBindings extracts the controller binding URLs from the "openvr" section of the output of
+// AppInfoPrint. The root of the output, as well as the "common" section are checked for the "openvr" section. The
+// bindings are read from the "controller_bindings" section within the "openvr" section if there is one, otherwise they
+// are read from the "openvr" section itself. The returned map is keyed by controller type, i.e. "vive" or "knuckles".
+// If the "openvr" section cannot be found then ErrFieldNotFound is returned.
+func AppOpenVRControllerBindings(output map[string]any) (map[string]string, error) {
+	openVR, path, err := appOpenVR(output)
+	if err != nil {
+		return nil, err
+	}
+
+	if value, ok := openVR["controller_bindings"]; ok {
+		if openVR, ok = value.(map[string]any); !ok {
+			return nil, errors.Errorf("%s.controller_bindings is not a map, it is a %T", path, value)
+		}
+	}
+
+	bindings := make(map[string]string)
+	for controllerType, value := range openVR {
+		if url, ok := value.(string); ok {
+			bindings[controllerType] = strings.TrimSpace(url)
+		}
+	}
+	return bindings, nil
+}
+
+// AppSupportsVR checks whether the output of AppInfoPrint has an "openvr" section.
+func AppSupportsVR(output map[string]any) bool {
+	_, _, err := appOpenVR(output)
+	return err == nil
+}
+
+// localizationPaths are the paths that the "localization" section can be found at.
+var localizationPaths = []string{"localization", "common.localization"}
+
+// AppLocalizationPaths extracts the "localization" section from the output of AppInfoPrint. The root of the output, as
+// well as the "common" section are checked for the section. The returned map is keyed by locale code, and the values
+// are the paths/URLs of the localised content for that locale. If the section cannot be found then ErrFieldNotFound is
+// returned.
+func AppLocalizationPaths(output map[string]any) (map[string]string, error) {
+	for _, path := range localizationPaths {
+		value, err := GetNestedValue(output, path)
+		if err != nil {
+			continue
+		}
+
+		localization, ok := value.(map[string]any)
+		if !ok {
+			return nil, errors.Errorf("%s is not a map, it is a %T", path, value)
+		}
+
+		paths := make(map[string]string)
+		for locale, contentPath := range localization {
+			if _, isMap := contentPath.(map[string]any); !isMap {
+				paths[locale] = toString(contentPath)
+			}
+		}
+		return paths, nil
+	}
+	return nil, errors.Wrap(ErrFieldNotFound, "cannot find a localization section")
+}
+
+// AppHasLocalization checks whether the output of AppInfoPrint has localised content for the given locale code using
+// AppLocalizationPaths.
+func AppHasLocalization(output map[string]any, locale string) bool {
+	paths, err := AppLocalizationPaths(output)
+	if err != nil {
+		return false
+	}
+	_, ok := paths[locale]
+	return ok
+}
+
+// clientBetaSuffix is the suffix of the fields within the "extended" section of the output of AppInfoPrint that contain
+// the platform-specific beta programs of an app.
+const clientBetaSuffix = "clientbeta"
+
+// AppClientBetaForPlatform extracts the "<platform>clientbeta" field from the "extended" section of the output of
+// AppInfoPrint. I.e. the platform "linux" will read the "linuxclientbeta" field. If the field cannot be found then
+// ErrFieldNotFound is returned.
+func AppClientBetaForPlatform(output map[string]any, platform string) (string, error) {
+	return getNestedString(output, "extended."+platform+clientBetaSuffix)
+}
+
+// AppAllClientBetas returns all the fields within the "extended" section of the output of AppInfoPrint that end with
+// "clientbeta", keyed by their full key. I.e. "linuxclientbeta". An empty map is returned if there are none.
+func AppAllClientBetas(output map[string]any) map[string]string {
+	betas := make(map[string]string)
+	value, err := GetNestedValue(output, "extended")
+	if err != nil {
+		return betas
+	}
+
+	if extended, ok := value.(map[string]any); ok {
+		for key, beta := range extended {
+			if _, isMap := beta.(map[string]any); !isMap && strings.HasSuffix(key, clientBetaSuffix) {
+				betas[key] = toString(beta)
+			}
+		}
+	}
+	return betas
+}
+
+// AppIsHidden checks whether the "hidden" field within the "common" section of the output of AppInfoPrint is set to
+// "1". Hidden apps are not shown on the Steam store, and usually have been removed. False is returned if the field
+// cannot be found.
+func AppIsHidden(output map[string]any) bool {
+	hidden, err := getNestedString(output, "common.hidden")
+	return err == nil && hidden == "1"
+}
+
+const (
+	// VisibilityPublic is the visibility state of an app that is visible to everyone.
+	VisibilityPublic = iota
+	// VisibilityFriendsOnly is the visibility state of an app that is only visible to friends.
+	VisibilityFriendsOnly
+	// VisibilityPrivate is the visibility state of an app that is not visible to anyone.
+	VisibilityPrivate
+	// VisibilityUnlisted is the visibility state of an app that is visible, but is not listed on the Steam store.
+	VisibilityUnlisted
+)
+
+// visibilityStatePaths are the paths that the "visibilitystate" field can be found at.
+var visibilityStatePaths = []string{"common.visibilitystate", "visibilitystate"}
+
+// AppVisibilityState extracts the "visibilitystate" field from the output of AppInfoPrint. The "common" section, as
+// well as the root of the output are checked for the field. The returned value is one of the following Steam
+// visibility states:
+//
+//	0 (VisibilityPublic)
+//	1 (VisibilityFriendsOnly)
+//	2 (VisibilityPrivate)
+//	3 (VisibilityUnlisted)
+//
+// VisibilityPublic is returned if the field cannot be found, or cannot be parsed as an integer.
+func AppVisibilityState(output map[string]any) int {
+	for _, path := range visibilityStatePaths {
+		if state, err := getNestedInt64(output, path); err == nil {
+			return int(state)
+		}
+	}
+	return VisibilityPublic
+}
+
+// priceOverviewPaths are the paths that a "price_overview" section can be found at. This section is in the same format
+// as the "price_overview" returned by the Steam store's appdetails API.
+var priceOverviewPaths = []string{"common.price_overview", "extended.price_overview", "price_overview"}
+
+// AppOriginalPrice extracts the original (un-discounted) price of the app in cents, as well as the currency of that
+// price, from the "price_overview" section of the output of AppInfoPrint. The "common", and "extended" sections, as
+// well as the root of the output are checked for a "price_overview" with "initial" and "currency" fields.
+//
+// Note that most apps do not expose their prices within the output of AppInfoPrint, as prices are region-specific and
+// are usually only available through the Steam store. If no price can be found then ErrFieldNotFound is returned.
+func AppOriginalPrice(output map[string]any) (cents int64, currency string, err error) {
+	for _, path := range priceOverviewPaths {
+		if currency, err = getNestedString(output, path+".currency"); err != nil {
+			continue
+		}
+
+		if _, err = GetNestedValue(output, path+".initial"); err != nil {
+			continue
+		}
+
+		if cents, err = getNestedInt64(output, path+".initial"); err != nil {
+			return 0, "", errors.Wrapf(err, "could not parse %s.initial", path)
+		}
+		return
+	}
+	return 0, "", errors.Wrap(ErrFieldNotFound, "cannot find a price_overview")
+}
+
+// getNestedFirst will return the value at the given path using GetNestedValue. If the value is a map of index to value
+// (i.e. {"0": "Bob", "1": "Alice"}) then the value at index "0" will be returned. Otherwise, the value is returned as a
+// string.
+func getNestedFirst(output map[string]any, path string) (string, error) {
+	value, err := GetNestedValue(output, path)
+	if err != nil {
+		return "", err
+	}
+
+	if m, ok := value.(map[string]any); ok {
+		if value, ok = m["0"]; !ok {
+			return "", errors.Wrapf(ErrFieldNotFound, "%q does not have an entry at index 0", path)
+		}
+	}
+	return toString(value), nil
+}
+
+// AppDeveloper extracts the "developer" field from the "common" section of the output of AppInfoPrint. The field can
+// either be a string, or a map of index to developer name, in which case the first developer is returned. If the field
+// cannot be found then ErrFieldNotFound is returned.
+func AppDeveloper(output map[string]any) (string, error) {
+	return getNestedFirst(output, "common.developer")
+}
+
+// AppPublisher extracts the "publisher" field from the "common" section of the output of AppInfoPrint. The field can
+// either be a string, or a map of index to publisher name, in which case the first publisher is returned. If the field
+// cannot be found then ErrFieldNotFound is returned.
+func AppPublisher(output map[string]any) (string, error) {
+	return getNestedFirst(output, "common.publisher")
+}
+
+// Genre is a genre that an app belongs to.
+type Genre struct {
+	ID          int
+	Description string
+}
+
+// The IDs of the genres within SteamGenreNames that signal adult only content.
+const (
+	// GenreSexualContent is the ID of the "Sexual Content" genre.
+	GenreSexualContent = 71
+	// GenreNudity is the ID of the "Nudity" genre.
+	GenreNudity = 72
+)
+
+// SteamGenreNames contains the descriptions of all the known Steam genres, keyed by their ID. These are used when the
+// output of AppInfoPrint does not contain a description for a genre.
+var SteamGenreNames = map[int]string{
+	1:  "Action",
+	2:  "Strategy",
+	3:  "RPG",
+	4:  "Casual",
+	9:  "Racing",
+	18: "Sports",
+	23: "Indie",
+	25: "Adventure",
+	28: "Simulation",
+	29: "Massively Multiplayer",
+	37: "Free to Play",
+	51: "Animation & Modeling",
+	52: "Audio Production",
+	53: "Design & Illustration",
+	54: "Education",
+	55: "Photo Editing",
+	56: "Software Training",
+	57: "Utilities",
+	58: "Video Production",
+	59: "Web Publishing",
+	60: "Game Development",
+	70: "Early Access",
+	71: "Sexual Content",
+	72: "Nudity",
+	73: "Violent",
+	74: "Gore",
+	81: "Documentary",
+	84: "Tutorial",
+}
+
+// newGenre creates a Genre from the given ID and description. If the description is empty, then the description will
+// be looked up in SteamGenreNames.
+func newGenre(id any, description string) (Genre, error) {
+	genreID, err := toInt64(id)
+	if err != nil {
+		return Genre{}, errors.Wrapf(err, "could not parse genre ID %v", id)
+	}
+
+	if description == "" {
+		description = SteamGenreNames[int(genreID)]
+	}
+	return Genre{ID: int(genreID), Description: description}, nil
+}
+
+// AppGenres extracts the "genres" section from the "common" section of the output of AppInfoPrint. This section is a
+// map of index to genre, where each genre is either a map containing an "id" and a "description", or just the ID of
+// the genre. Genres without a description will use the description from SteamGenreNames. The genres are returned
+// sorted by their ID. If the section cannot be found then ErrFieldNotFound is returned.
+func AppGenres(output map[string]any) ([]Genre, error) {
+	value, err := GetNestedValue(output, "common.genres")
+	if err != nil {
+		return nil, err
+	}
+
+	genresMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, errors.Errorf("genres is not a map, it is a %T", value)
+	}
+
+	genres := make([]Genre, 0, len(genresMap))
+	for index, genreValue := range genresMap {
+		var genre Genre
+		switch g := genreValue.(type) {
+		case map[string]any:
+			if _, ok = g["id"]; !ok {
+				return nil, errors.Wrapf(ErrFieldNotFound, "genre at index %s does not have an id", index)
+			}
+
+			description := ""
+			if d, ok := g["description"]; ok {
+				description = toString(d)
+			}
+
+			if genre, err = newGenre(g["id"], description); err != nil {
+				return nil, errors.Wrapf(err, "could not parse genre at index %s", index)
+			}
+		default:
+			if genre, err = newGenre(g, ""); err != nil {
+				return nil, errors.Wrapf(err, "could not parse genre at index %s", index)
+			}
+		}
+		genres = append(genres, genre)
+	}
+
+	sort.Slice(genres, func(i, j int) bool {
+		return genres[i].ID < genres[j].ID
+	})
+	return genres, nil
+}
+
+// AppHasGenre checks whether the output of AppInfoPrint has a genre with the given ID.
+func AppHasGenre(output map[string]any, genreID int) bool {
+	genres, _ := AppGenres(output)
+	for _, genre := range genres {
+		if genre.ID == genreID {
+			return true
+		}
+	}
+	return false
+}
+
+// AppPrimaryGenre extracts the "primary_genre" ID from the "common" section of the output of AppInfoPrint, and returns
+// it as a Genre with the description from SteamGenreNames. If the ID is not in SteamGenreNames, then the description
+// will be empty. If the field cannot be found then ErrFieldNotFound is returned.
+func AppPrimaryGenre(output map[string]any) (Genre, error) {
+	value, err := GetNestedValue(output, "common.primary_genre")
+	if err != nil {
+		return Genre{}, err
+	}
+	return newGenre(value, "")
+}
+
+// appIndexedTags extracts the section at the given path of the output of AppInfoPrint. This section is a map of index
+// to the ID of a user tag on the Steam store (i.e. "1664" for "Puzzle"). The tag IDs are returned in the order of their
+// index. Nil is returned if the section cannot be found.
+func appIndexedTags(output map[string]any, path string) []string {
+	value, err := GetNestedValue(output, path)
+	if err != nil {
+		return nil
+	}
+
+	tagsMap, ok := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	indices := make([]string, 0, len(tagsMap))
+	for index := range tagsMap {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return lessNumeric(indices[i], indices[j])
+	})
+
+	tags := make([]string, len(indices))
+	for i, index := range indices {
+		tags[i] = toString(tagsMap[index])
+	}
+	return tags
+}
+
+// AppStoreTags extracts the IDs of the tags in the "store_tags" section from the "common" section of the output of
+// AppInfoPrint. These are the tags that are curated by Valve for the app's store page. The tag IDs are returned in the
+// order of their index. Nil is returned if the section cannot be found.
+func AppStoreTags(output map[string]any) []string {
+	return appIndexedTags(output, "common.store_tags")
+}
+
+// AppUserDefinedTags is the same as AppStoreTags, but the "user_defined_tags" section from the "common" section of the
+// output of AppInfoPrint is used instead. Most apps do not have this section, in which case nil is returned.
+func AppUserDefinedTags(output map[string]any) []string {
+	return appIndexedTags(output, "common.user_defined_tags")
+}
+
+// AppTags extracts the "store_tags" section from the "common" section of the output of AppInfoPrint.
+//
+// Deprecated: AppTags has been renamed to AppStoreTags, to distinguish it from AppUserDefinedTags.
+func AppTags(output map[string]any) []string {
+	return AppStoreTags(output)
+}
+
+// AppHasTag checks whether the output of AppInfoPrint has the tag with the given ID using AppStoreTags.
+func AppHasTag(output map[string]any, tag string) bool {
+	for _, t := range AppStoreTags(output) {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// lessNumeric compares the two given strings as integers if they can both be parsed as integers. Otherwise, they are
+// compared lexicographically.
+func lessNumeric(a, b string) bool {
+	ai, aErr := strconv.ParseInt(a, 10, 64)
+	bi, bErr := strconv.ParseInt(b, 10, 64)
+	if aErr == nil && bErr == nil {
+		return ai < bi
+	}
+	return a < b
+}
+
+// AppLanguages extracts the "languages" section from the "common" section of the output of AppInfoPrint. This can
+// either be a comma-separated string of languages, or a map of language to "1". Languages mapped to "0" in the latter
+// format are not included. The languages are returned sorted. If the section cannot be found then ErrFieldNotFound is
+// returned.
+func AppLanguages(output map[string]any) ([]string, error) {
+	value, err := GetNestedValue(output, "common.languages")
+	if err != nil {
+		return nil, err
+	}
+
+	var languages []string
+	switch v := value.(type) {
+	case map[string]any:
+		languages = make([]string, 0, len(v))
+		for language, supported := range v {
+			if language = strings.TrimSpace(language); language != "" && toString(supported) != "0" {
+				languages = append(languages, language)
+			}
+		}
+	default:
+		languages = splitList(toString(v))
+	}
+	sort.Strings(languages)
+	return languages, nil
+}
+
+// AppSupportsLanguage checks whether the output of AppInfoPrint lists the given language in its "languages" section.
+// Languages are compared case-insensitively.
+func AppSupportsLanguage(output map[string]any, lang string) bool {
+	languages, _ := AppLanguages(output)
+	for _, language := range languages {
+		if strings.EqualFold(language, lang) {
+			return true
+		}
+	}
+	return false
+}
+
+// AdultRequiredAge is the minimum "required_age" for an app to be considered adult content by AppIsAdultContent.
+const AdultRequiredAge = 18
+
+// AppRequiredAge extracts the "required_age" field from the "common" section of the output of AppInfoPrint. If the
+// field cannot be found then ErrFieldNotFound is returned.
+func AppRequiredAge(output map[string]any) (int, error) {
+	age, err := getNestedInt64(output, "common.required_age")
+	if err != nil {
+		return 0, errors.Wrap(err, "could not parse required_age")
+	}
+	return int(age), nil
+}
+
+// AppIsAdultContent checks whether the "required_age" of the output of AppInfoPrint is at least AdultRequiredAge.
+func AppIsAdultContent(output map[string]any) bool {
+	age, err := AppRequiredAge(output)
+	return err == nil && age >= AdultRequiredAge
+}
+
+// ContentDescriptor is a mature content descriptor for an app.
+type ContentDescriptor struct {
+	ID          int
+	Description string
+}
+
+// The IDs of the content descriptors that Steam uses.
+const (
+	// ContentDescriptorNudity is for apps with some nudity or sexual content.
+	ContentDescriptorNudity = 1
+	// ContentDescriptorViolence is for apps with frequent violence or gore.
+	ContentDescriptorViolence = 2
+	// ContentDescriptorAdultOnlySexualContent is for apps with adult only sexual content.
+	ContentDescriptorAdultOnlySexualContent = 3
+	// ContentDescriptorFrequentNudity is for apps with frequent nudity or sexual content.
+	ContentDescriptorFrequentNudity = 4
+	// ContentDescriptorGeneralMature is for apps with general mature content.
+	ContentDescriptorGeneralMature = 5
+)
+
+// contentDescriptorDescriptions are the descriptions of the known content descriptors. These are used when the output
+// of AppInfoPrint does not contain a description.
+var contentDescriptorDescriptions = map[int]string{
+	ContentDescriptorNudity:                 "Some Nudity or Sexual Content",
+	ContentDescriptorViolence:               "Frequent Violence or Gore",
+	ContentDescriptorAdultOnlySexualContent: "Adult Only Sexual Content",
+	ContentDescriptorFrequentNudity:         "Frequent Nudity or Sexual Content",
+	ContentDescriptorGeneralMature:          "General Mature Content",
+}
+
+// newContentDescriptor creates a ContentDescriptor from the given ID and description. If the description is empty
+// then the description from contentDescriptorDescriptions is used.
+func newContentDescriptor(id any, description string) (ContentDescriptor, error) {
+	id64, err := toInt64(id)
+	if err != nil {
+		return ContentDescriptor{}, errors.Wrap(err, "could not parse content descriptor ID")
+	}
+
+	if description == "" {
+		description = contentDescriptorDescriptions[int(id64)]
+	}
+	return ContentDescriptor{ID: int(id64), Description: description}, nil
+}
+
+// AppContentDescriptors extracts the "content_descriptors" section from the "common" section of the output of
+// AppInfoPrint. This section can either contain an "ids" field that is a comma-separated list of content descriptor IDs,
+// or be a map of index to content descriptor, where each content descriptor is either a map containing an "id" and a
+// "description", or just the ID of the content descriptor. The content descriptors are returned sorted by their ID. If
+// the section cannot be found then ErrFieldNotFound is returned.
+func AppContentDescriptors(output map[string]any) ([]ContentDescriptor, error) {
+	value, err := GetNestedValue(output, "common.content_descriptors")
+	if err != nil {
+		return nil, err
+	}
+
+	descriptors := make([]ContentDescriptor, 0)
+	appendIDs := func(ids string) error {
+		for _, id := range splitList(ids) {
+			descriptor, err := newContentDescriptor(id, "")
+			if err != nil {
+				return err
+			}
+			descriptors = append(descriptors, descriptor)
+		}
+		return nil
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		if ids, ok := v["ids"]; ok {
+			if err = appendIDs(toString(ids)); err != nil {
+				return nil, err
+			}
+			break
+		}
+
+		for index, descriptorValue := range v {
+			var descriptor ContentDescriptor
+			switch d := descriptorValue.(type) {
+			case map[string]any:
+				if _, ok := d["id"]; !ok {
+					return nil, errors.Wrapf(ErrFieldNotFound, "content descriptor at index %s does not have an id", index)
+				}
+				var description string
+				if descriptionValue, ok := d["description"]; ok {
+					description = toString(descriptionValue)
+				}
+				descriptor, err = newContentDescriptor(d["id"], description)
+			default:
+				descriptor, err = newContentDescriptor(d, "")
+			}
+
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not parse content descriptor at index %s", index)
+			}
+			descriptors = append(descriptors, descriptor)
+		}
+	default:
+		if err = appendIDs(toString(v)); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Slice(descriptors, func(i, j int) bool {
+		return descriptors[i].ID < descriptors[j].ID
+	})
+	return descriptors, nil
+}
+
+// AppHasContentDescriptor checks whether the output of AppInfoPrint has a content descriptor with the given ID.
+func AppHasContentDescriptor(output map[string]any, id int) bool {
+	descriptors, _ := AppContentDescriptors(output)
+	for _, descriptor := range descriptors {
+		if descriptor.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// adultContentDescriptors are the IDs of the content descriptors that are considered adult only by
+// AppAdultContentReason.
+var adultContentDescriptors = []int{ContentDescriptorAdultOnlySexualContent, ContentDescriptorFrequentNudity}
+
+// adultGenres are the IDs of the genres within SteamGenreNames that are considered adult only by
+// AppAdultContentReason.
+var adultGenres = []int{GenreSexualContent, GenreNudity}
+
+// AdultOnlyCategories are the IDs of the categories (see AppCategories) that are considered adult only by
+// AppAdultContentReason. Steam does not have a category of its own for adult only content, so this is empty by
+// default. The IDs of any categories that are used to flag adult only content can be added to this.
+var AdultOnlyCategories []int
+
+// AppAdultContentReason returns a human-readable reason for each adult only signal that the output of AppInfoPrint
+// matches. The signals are checked in the following order of priority:
+//
+//  1. The "required_age" is at least AdultRequiredAge (see AppIsAdultContent).
+//  2. The app has the ContentDescriptorAdultOnlySexualContent or ContentDescriptorFrequentNudity content descriptors.
+//  3. The app has any of the AdultOnlyCategories.
+//  4. The app has the GenreSexualContent or GenreNudity genres.
+//
+// As AdultOnlyCategories is empty by default, the adult only genres are also checked, as these are the flags that
+// Steam actually uses. An empty slice is returned if none of the signals match.
+func AppAdultContentReason(output map[string]any) []string {
+	reasons := make([]string, 0)
+	if age, err := AppRequiredAge(output); err == nil && age >= AdultRequiredAge {
+		reasons = append(reasons, fmt.Sprintf("required age is %d", age))
+	}
+
+	descriptors, _ := AppContentDescriptors(output)
+	for _, descriptor := range descriptors {
+		for _, id := range adultContentDescriptors {
+			if descriptor.ID == id {
+				reasons = append(reasons, fmt.Sprintf("has the %q content descriptor", descriptor.Description))
+			}
+		}
+	}
+
+	categories, _ := AppCategories(output)
+	for _, category := range categories {
+		for _, id := range AdultOnlyCategories {
+			if category.ID == id {
+				if category.Description != "" {
+					reasons = append(reasons, fmt.Sprintf("has the %q category", category.Description))
+				} else {
+					reasons = append(reasons, fmt.Sprintf("has the category with ID %d", category.ID))
+				}
+			}
+		}
+	}
+
+	genres, _ := AppGenres(output)
+	for _, genre := range genres {
+		for _, id := range adultGenres {
+			if genre.ID == id {
+				reasons = append(reasons, fmt.Sprintf("has the %q genre", genre.Description))
+			}
+		}
+	}
+	return reasons
+}
+
+// AppIsAdultOnly checks whether the output of AppInfoPrint matches any of the adult only signals that are checked by
+// AppAdultContentReason.
+func AppIsAdultOnly(output map[string]any) bool {
+	return len(AppAdultContentReason(output)) > 0
+}
+
+// AppImageURL extracts the image URL with the given key from the "common" section of the output of AppInfoPrint. I.e.
+// "header_image". These fields are not always present in the output of AppInfoPrint, as some images are only available
+// on the store page. If the field cannot be found then ErrFieldNotFound is returned.
+func AppImageURL(output map[string]any, key string) (string, error) {
+	value, err := GetNestedValue(output, "common."+key)
+	if err != nil {
+		return "", err
+	}
+
+	url, ok := value.(string)
+	if !ok {
+		return "", errors.Errorf("%s is not a string, it is a %T", key, value)
+	}
+	return strings.TrimSpace(url), nil
+}
+
+// AppHeaderImageURL extracts the "header_image" URL from the "common" section of the output of AppInfoPrint using
+// AppImageURL.
+func AppHeaderImageURL(output map[string]any) (string, error) {
+	return AppImageURL(output, "header_image")
+}
+
+// AppCapsuleImageURL extracts the "capsule_image" URL from the "common" section of the output of AppInfoPrint using
+// AppImageURL.
+func AppCapsuleImageURL(output map[string]any) (string, error) {
+	return AppImageURL(output, "capsule_image")
+}
+
+// AppBackgroundImageURL extracts the "background_image" URL from the "common" section of the output of AppInfoPrint
+// using AppImageURL.
+func AppBackgroundImageURL(output map[string]any) (string, error) {
+	return AppImageURL(output, "background_image")
+}
+
+// SteamMediaBaseURL is the base URL of the Steam CDN that serves the icons and logos of apps.
+const SteamMediaBaseURL = "https://media.steampowered.com/steamcommunity/public/images/apps"
+
+// appMediaURL constructs the URL of the image on the Steam CDN that has the SHA1 hash stored in the field with the
+// given key within the "common" section of the output of AppInfoPrint.
+func appMediaURL(output map[string]any, key string, appID int64) (string, error) {
+	hash, err := AppImageURL(output, key)
+	if err != nil {
+		return "", err
+	}
+
+	if hash == "" {
+		return "", errors.Wrapf(ErrFieldNotFound, "%s is empty", key)
+	}
+	return fmt.Sprintf("%s/%d/%s.jpg", SteamMediaBaseURL, appID, hash), nil
+}
+
+// AppIconURL constructs the URL of the icon of the app with the given ID on the Steam CDN, using the SHA1 hash stored
+// in the "icon" field of the "common" section of the output of AppInfoPrint. I.e.
+// "<SteamMediaBaseURL>/<appID>/<icon>.jpg". If the field cannot be found then ErrFieldNotFound is returned.
+func AppIconURL(output map[string]any, appID int64) (string, error) {
+	return appMediaURL(output, "icon", appID)
+}
+
+// AppLogoURL is the same as AppIconURL, but the SHA1 hash stored in the "logo" field is used instead.
+func AppLogoURL(output map[string]any, appID int64) (string, error) {
+	return appMediaURL(output, "logo", appID)
+}
+
+// packageIDValues returns the package IDs within the given value from a "packages" section of the output of
+// AppInfoPrint. The value can either be a map of index to package ID, or a comma-separated list of package IDs.
+func packageIDValues(value any) []string {
+	switch v := value.(type) {
+	case map[string]any:
+		ids := make([]string, 0, len(v))
+		for _, id := range v {
+			ids = append(ids, splitList(toString(id))...)
+		}
+		return ids
+	default:
+		return splitList(toString(v))
+	}
+}
+
+// AppPackageIDs extracts the IDs of the packages that grant access to the app from the output of AppInfoPrint. The
+// package IDs are read from the "packages" section, as well as the "packages" of each group within the
+// "package_groups" section. Each "packages" section can either be a map of index to package ID, or a comma-separated
+// list of package IDs. The package IDs are returned sorted and without duplicates. If neither section can be found then
+// ErrFieldNotFound is returned.
+func AppPackageIDs(output map[string]any) ([]int64, error) {
+	values := make([]any, 0)
+	if packages, err := GetNestedValue(output, "packages"); err == nil {
+		values = append(values, packages)
+	}
+
+	if groupsValue, err := GetNestedValue(output, "package_groups"); err == nil {
+		groups, ok := groupsValue.(map[string]any)
+		if !ok {
+			return nil, errors.Errorf("package_groups is not a map, it is a %T", groupsValue)
+		}
+
+		for _, groupValue := range groups {
+			if group, ok := groupValue.(map[string]any); ok {
+				if packages, ok := group["packages"]; ok {
+					values = append(values, packages)
+				}
+			}
+		}
+	}
+
+	if len(values) == 0 {
+		return nil, errors.Wrap(ErrFieldNotFound, "cannot find a packages or package_groups section")
+	}
+
+	seen := make(map[int64]struct{})
+	packageIDs := make([]int64, 0)
+	for _, value := range values {
+		for _, id := range packageIDValues(value) {
+			packageID, err := toInt64(id)
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not parse package ID %q", id)
+			}
+
+			if _, ok := seen[packageID]; !ok {
+				seen[packageID] = struct{}{}
+				packageIDs = append(packageIDs, packageID)
+			}
+		}
+	}
+
+	sort.Slice(packageIDs, func(i, j int) bool {
+		return packageIDs[i] < packageIDs[j]
+	})
+	return packageIDs, nil
+}
+
+// AppInstallDirName extracts the "installdir" field from the "config" section of the output of AppInfoPrint. This is
+// the name of the directory that steamcmd installs the app to within the "steamapps/common" directory of a Steam
+// library. If the field cannot be found then ErrFieldNotFound is returned.
+func AppInstallDirName(output map[string]any) (string, error) {
+	return getNestedString(output, "config.installdir")
+}
+
+// AppDefaultInstallPath returns the path that steamcmd installs the app to by default within the given Steam library.
+// I.e. "<steamLibrary>/steamapps/common/<installdir>". The path is joined using the path separator of the current OS.
+// If the "installdir" cannot be found using AppInstallDirName then an empty string is returned.
+func AppDefaultInstallPath(output map[string]any, steamLibrary string) string {
+	installDir, err := AppInstallDirName(output)
+	if err != nil {
+		return ""
+	}
+	return joinInstallPath(string(filepath.Separator), steamLibrary, installDir)
+}
+
+// joinInstallPath joins the given Steam library and install directory name using the given path separator. This is
+// separate from AppDefaultInstallPath so that paths can be constructed for a different OS.
+func joinInstallPath(separator string, steamLibrary string, installDir string) string {
+	installPath := strings.Join([]string{"steamapps", "common", installDir}, separator)
+	if steamLibrary == "" {
+		return installPath
+	}
+	// Trailing separators are trimmed so that we don't end up with duplicate separators. If the Steam library is the
+	// root directory then this will leave just the leading separator.
+	return strings.TrimRight(steamLibrary, `/\`) + separator + installPath
+}
+
+// PublicBranch is the name of the default branch of an app.
+const PublicBranch = "public"
+
+// AppBuildID extracts the "buildid" of the PublicBranch from the "depots"."branches" section of the output of
+// AppInfoPrint. If the field cannot be found then ErrFieldNotFound is returned.
+func AppBuildID(output map[string]any) (int64, error) {
+	return AppBuildIDForBranch(output, PublicBranch)
+}
+
+// AppBuildIDForBranch is the same as AppBuildID, but the "buildid" of the given branch will be extracted instead.
+func AppBuildIDForBranch(output map[string]any, branch string) (int64, error) {
+	buildID, err := getNestedInt64(output, "depots.branches."+branch+".buildid")
+	if err != nil {
+		return 0, errors.Wrapf(err, "could not parse buildid for branch %q", branch)
+	}
+	return buildID, nil
+}
+
+// AppLastUpdated extracts the "timeupdated" Unix timestamp of the PublicBranch from the "depots"."branches" section of
+// the output of AppInfoPrint. If the field cannot be found then ErrFieldNotFound is returned.
+func AppLastUpdated(output map[string]any) (time.Time, error) {
+	timeUpdated, err := getNestedString(output, "depots.branches."+PublicBranch+".timeupdated")
+	if err != nil {
+		return time.Time{}, err
+	}
+	return SteamDateLayout(UnixTimestamp).Parse(timeUpdated)
+}
+
+// ErrNoFreeWeekend is returned by AppFreeWeekend and AppIsFreeWeekendNow when the output of AppInfoPrint does not have
+// a "free_weekend" section.
+type ErrNoFreeWeekend struct {
+	// AppID is the ID of the app that has no free weekend. This will be 0 if the output has no "appid".
+	AppID int64
+}
+
+func (e ErrNoFreeWeekend) Error() string {
+	return fmt.Sprintf("app %d has no free weekend", e.AppID)
+}
+
+// FreeWeekend is the period of time that an app is temporarily free to play.
+type FreeWeekend struct {
+	Start time.Time
+	End   time.Time
+}
+
+// freeWeekendPaths are the paths that the "free_weekend" section can be found at.
+var freeWeekendPaths = []string{"common.free_weekend", "extended.free_weekend", "free_weekend"}
+
+// AppFreeWeekend extracts the "free_weekend" section from the output of AppInfoPrint. The "common" and "extended"
+// sections, as well as the root of the output are checked for the section. The "start" and "end" fields of the section
+// are parsed as Unix timestamps. If the section cannot be found then ErrNoFreeWeekend is returned.
+func AppFreeWeekend(output map[string]any) (*FreeWeekend, error) {
+	for _, path := range freeWeekendPaths {
+		if _, err := GetNestedValue(output, path); err != nil {
+			continue
+		}
+
+		var (
+			freeWeekend FreeWeekend
+			err         error
+		)
+		for key, field := range map[string]*time.Time{"start": &freeWeekend.Start, "end": &freeWeekend.End} {
+			var timestamp string
+			if timestamp, err = getNestedString(output, path+"."+key); err != nil {
+				return nil, errors.Wrapf(err, "could not find the %s of the free weekend", key)
+			}
+
+			if *field, err = SteamDateLayout(UnixTimestamp).Parse(timestamp); err != nil {
+				return nil, errors.Wrapf(err, "could not parse the %s of the free weekend", key)
+			}
+		}
+		return &freeWeekend, nil
+	}
+
+	appID, _ := AppID(output)
+	return nil, ErrNoFreeWeekend{AppID: appID}
+}
+
+// AppIsFreeWeekendNow checks whether the current time is within the FreeWeekend returned by AppFreeWeekend. If the
+// output of AppInfoPrint does not have a "free_weekend" section then ErrNoFreeWeekend is returned.
+func AppIsFreeWeekendNow(output map[string]any) (bool, error) {
+	return appIsFreeWeekendAt(output, time.Now().UTC())
+}
+
+// appIsFreeWeekendAt is the implementation for AppIsFreeWeekendNow that checks against the given time.
+func appIsFreeWeekendAt(output map[string]any, now time.Time) (bool, error) {
+	freeWeekend, err := AppFreeWeekend(output)
+	if err != nil {
+		return false, err
+	}
+	return !now.Before(freeWeekend.Start) && now.Before(freeWeekend.End), nil
+}
+
+// AppStoreAssetMTime extracts the "store_asset_mtime" Unix timestamp from the "common" section of the output of
+// AppInfoPrint. This is the last time that the store assets (i.e. the capsule images) of the app were modified. If the
+// field cannot be found then ErrFieldNotFound is returned.
+func AppStoreAssetMTime(output map[string]any) (time.Time, error) {
+	mtime, err := getNestedString(output, "common.store_asset_mtime")
+	if err != nil {
+		return time.Time{}, err
+	}
+	return SteamDateLayout(UnixTimestamp).Parse(mtime)
+}
+
+// AppHasAssetChangedSince checks whether the store assets of the app have been modified after the given time using
+// AppStoreAssetMTime. False is returned if the "store_asset_mtime" field cannot be found.
+func AppHasAssetChangedSince(output map[string]any, since time.Time) bool {
+	mtime, err := AppStoreAssetMTime(output)
+	return err == nil && mtime.After(since)
+}
+
+// appInfoHeaderChangeNumberPattern matches the change number within the header line of the output of AppInfoPrint.
+// I.e. "AppID : 477160, change number : 16411497/0, last change : Fri Oct  7 14:32:33 2022".
+var appInfoHeaderChangeNumberPattern = regexp.MustCompile(`change number : (\d+)`)
+
+// HeaderChangeNumber extracts the change number from the header line of the given raw output of AppInfoPrint. This is
+// the change number of the app within Steam's PICS, which can differ from the MapChangeNumber.
+func HeaderChangeNumber(rawOutput []byte) (int64, error) {
+	match := appInfoHeaderChangeNumberPattern.FindSubmatch(rawOutput)
+	if match == nil {
+		return 0, errors.Wrap(ErrFieldNotFound, "cannot find the change number in the app_info_print output")
+	}
+
+	changeNumber, err := strconv.ParseInt(string(match[1]), 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not parse the change number in the app_info_print output")
+	}
+	return changeNumber, nil
+}
+
+// MapChangeNumber extracts the "app_info_change_number" field from the output of AppInfoPrint. The root of the output,
+// as well as the "common" section are checked for the field. If the field cannot be found then ErrFieldNotFound is
+// returned. Note that this can differ from the HeaderChangeNumber of the raw output.
+func MapChangeNumber(output map[string]any) (int64, error) {
+	for _, path := range []string{"app_info_change_number", "common.app_info_change_number"} {
+		if _, err := GetNestedValue(output, path); err == nil {
+			return getNestedInt64(output, path)
+		}
+	}
+	return 0, errors.Wrap(ErrFieldNotFound, "cannot find an app_info_change_number")
+}
+
+// ChangeNumber extracts the change number from the parsed output of AppInfoPrint using MapChangeNumber. To get the
+// change number from the header line of the raw output, use HeaderChangeNumber instead.
+func ChangeNumber(output map[string]any) (int64, error) {
+	return MapChangeNumber(output)
+}
+
+// Manifest is the manifest of a depot for a branch, as found in the "manifests" section of a depot in the output of
+// AppInfoPrint.
+type Manifest struct {
+	// ManifestID is the ID of the manifest. For newer outputs this is the same as the GID, but older outputs only store
+	// the manifest ID as the value of the branch.
+	ManifestID string
+	// Size is the size of the depot's content in bytes once installed.
+	Size int64
+	// Download is the size of the depot's content in bytes that needs to be downloaded.
+	Download int64
+	// GID is the "gid" field of the manifest. This will be empty for older outputs.
+	GID string
+}
+
+// ParseManifests parses the "manifests" section of the given depot from the "depots" section of the output of
+// AppInfoPrint. The returned map is keyed by branch name. If the depot has no "manifests" section then ErrFieldNotFound
+// is returned.
+func ParseManifests(depotData map[string]any) (map[string]Manifest, error) {
+	value, err := GetNestedValue(depotData, "manifests")
+	if err != nil {
+		return nil, err
+	}
+
+	manifestsData, ok := value.(map[string]any)
+	if !ok {
+		return nil, errors.Errorf("manifests is not a map, it is a %T", value)
+	}
+
+	manifests := make(map[string]Manifest)
+	for branch, manifestValue := range manifestsData {
+		var manifest Manifest
+		switch manifestData := manifestValue.(type) {
+		case map[string]any:
+			if gid, ok := manifestData["gid"]; ok {
+				manifest.GID = toString(gid)
+				manifest.ManifestID = manifest.GID
+			}
+
+			for key, field := range map[string]*int64{"size": &manifest.Size, "download": &manifest.Download} {
+				if sizeValue, ok := manifestData[key]; ok {
+					if *field, err = toInt64(sizeValue); err != nil {
+						return nil, errors.Wrapf(err, "could not parse %s of manifest for branch %q", key, branch)
+					}
+				}
+			}
+		default:
+			manifest.ManifestID = toString(manifestData)
+		}
+		manifests[branch] = manifest
+	}
+	return manifests, nil
+}
+
+// AppPublicManifest extracts the Manifest of the PublicBranch for the depot with the given ID from the "depots"
+// section of the output of AppInfoPrint. If the depot, or its public manifest, cannot be found then ErrFieldNotFound
+// is returned.
+func AppPublicManifest(output map[string]any, depotID string) (*Manifest, error) {
+	value, err := GetNestedValue(output, "depots."+depotID)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not find depot %s", depotID)
+	}
+
+	depotData, ok := value.(map[string]any)
+	if !ok {
+		return nil, errors.Errorf("depot %s is not a map, it is a %T", depotID, value)
+	}
+
+	manifests, err := ParseManifests(depotData)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not parse manifests for depot %s", depotID)
+	}
+
+	manifest, ok := manifests[PublicBranch]
+	if !ok {
+		return nil, errors.Wrapf(ErrFieldNotFound, "depot %s has no manifest for branch %q", depotID, PublicBranch)
+	}
+	return &manifest, nil
+}
+
+// Depot is a depot from the "depots" section of the output of AppInfoPrint.
+type Depot struct {
+	// ID is the ID of the depot, which is also its key within the "depots" section.
+	ID string
+	// Name is the "name" of the depot. This will be empty for most outputs.
+	Name string
+	// OSList contains the OS from the "oslist" field of the depot's "config" section, split on commas. I.e. "windows".
+	// This will be empty if the depot is not specific to any OS.
+	OSList []string
+	// OSArch is the "osarch" field of the depot's "config" section. I.e. "64".
+	OSArch string
+	// Language is the "language" field of the depot's "config" section. This will be empty if the depot is not specific
+	// to any language.
+	Language string
+	// DLCAppID is the "dlcappid" field of the depot. This will be 0 if the depot does not belong to a DLC.
+	DLCAppID int64
+	// Binary is set when the depot contains platform-specific binaries. This is determined by whether the depot's
+	// "config" section has an "oslist" or "osbitmask" field, or whether the depot has a non-zero "binary" field.
+	Binary bool
+	// Manifests are the Manifest for each branch that are parsed using ParseManifests.
+	Manifests map[string]Manifest
+}
+
+// depotBinaryIndicators are the fields in a depot's "config" section that indicate that the depot contains
+// platform-specific binaries.
+var depotBinaryIndicators = []string{"oslist", "osbitmask"}
+
+// parseDepot parses the given depot data from the "depots" section of the output of AppInfoPrint.
+func parseDepot(depotID string, depotData map[string]any) (depot Depot, err error) {
+	depot = Depot{ID: depotID}
+	if name, ok := depotData["name"]; ok {
+		depot.Name = toString(name)
+	}
+
+	if dlcAppID, ok := depotData["dlcappid"]; ok {
+		if depot.DLCAppID, err = toInt64(dlcAppID); err != nil {
+			return depot, errors.Wrapf(err, "could not parse dlcappid of depot %s", depotID)
+		}
+	}
+
+	if binary, ok := depotData["binary"]; ok {
+		depot.Binary = toString(binary) != "" && toString(binary) != "0"
+	}
+
+	if config, ok := depotData["config"].(map[string]any); ok {
+		if oslist, ok := config["oslist"]; ok {
+			depot.OSList = splitList(toString(oslist))
+		}
+		if osarch, ok := config["osarch"]; ok {
+			depot.OSArch = toString(osarch)
+		}
+		if language, ok := config["language"]; ok {
+			depot.Language = toString(language)
+		}
+		for _, indicator := range depotBinaryIndicators {
+			if _, ok := config[indicator]; ok {
+				depot.Binary = true
+			}
+		}
+	}
+
+	if depot.Manifests, err = ParseManifests(depotData); err != nil && !errors.Is(err, ErrFieldNotFound) {
+		return depot, errors.Wrapf(err, "could not parse manifests for depot %s", depotID)
+	}
+	return depot, nil
+}
+
+// AppDepots parses each depot from the "depots" section of the output of AppInfoPrint. The returned map is keyed by
+// depot ID. Entries within the "depots" section that are not depots, such as "branches", are skipped. If there is no
+// "depots" section then ErrFieldNotFound is returned.
+func AppDepots(output map[string]any) (map[string]Depot, error) {
+	value, err := GetNestedValue(output, "depots")
+	if err != nil {
+		return nil, err
+	}
+
+	depotsData, ok := value.(map[string]any)
+	if !ok {
+		return nil, errors.Errorf("depots is not a map, it is a %T", value)
+	}
+
+	depots := make(map[string]Depot)
+	for depotID, depotValue := range depotsData {
+		depotData, ok := depotValue.(map[string]any)
+		if _, err = strconv.ParseInt(depotID, 10, 64); err != nil || !ok {
+			continue
+		}
+
+		if depots[depotID], err = parseDepot(depotID, depotData); err != nil {
+			return nil, err
+		}
+	}
+	return depots, nil
+}
+
+// AppBinaryDepots filters the depots returned by AppDepots to only those that contain platform-specific binaries. See
+// Depot.Binary for how this is determined.
+func AppBinaryDepots(output map[string]any) (map[string]Depot, error) {
+	depots, err := AppDepots(output)
+	if err != nil {
+		return nil, err
+	}
+
+	for depotID, depot := range depots {
+		if !depot.Binary {
+			delete(depots, depotID)
+		}
+	}
+	return depots, nil
+}
+
+// AppDepotForPlatform returns the binary depot (see AppBinaryDepots) that is most appropriate for the given Steam OS
+// name. I.e. "windows", "macos", or "linux". Depots that belong to a DLC, or that are specific to a language, are not
+// considered. Depots that are only for the given OS are preferred over depots that are shared between multiple OS, and
+// ties are broken by picking the depot with the lowest ID. If there is no depot for the given OS then ErrFieldNotFound
+// is returned.
+func AppDepotForPlatform(output map[string]any, platform string) (*Depot, error) {
+	depots, err := AppBinaryDepots(output)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]Depot, 0)
+	for _, depot := range depots {
+		if depot.DLCAppID != 0 || depot.Language != "" {
+			continue
+		}
+
+		for _, os := range depot.OSList {
+			if strings.EqualFold(os, platform) {
+				candidates = append(candidates, depot)
+				break
+			}
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, errors.Wrapf(ErrFieldNotFound, "cannot find a depot for platform %q", platform)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		if len(candidates[i].OSList) != len(candidates[j].OSList) {
+			return len(candidates[i].OSList) < len(candidates[j].OSList)
+		}
+		return lessNumeric(candidates[i].ID, candidates[j].ID)
+	})
+	return &candidates[0], nil
+}
+
+// LanguageSupport describes the level of support that an app has for a language.
+type LanguageSupport struct {
+	// Interface is whether the interface of the app supports the language.
+	Interface bool
+	// FullAudio is whether the app has full audio in the language.
+	FullAudio bool
+	// Subtitles is whether the app has subtitles in the language.
+	Subtitles bool
+}
+
+var (
+	// supportedLanguagesFootnotePattern matches the footnote at the end of the HTML-annotated "supported_languages"
+	// string. I.e. "<br><strong>*</strong>languages with full audio support".
+	supportedLanguagesFootnotePattern = regexp.MustCompile(`(?is)<br\s*/?>.*$`)
+	// supportedLanguagesFullAudioPattern matches the asterisk that marks a language with full audio support within the
+	// HTML-annotated "supported_languages" string.
+	supportedLanguagesFullAudioPattern = regexp.MustCompile(`(?i)<strong>\s*\*\s*</strong>|\*`)
+	// supportedLanguagesTagPattern matches any remaining HTML tags within the HTML-annotated "supported_languages"
+	// string.
+	supportedLanguagesTagPattern = regexp.MustCompile(`<[^>]*>`)
+)
+
+// AppSupportedLanguagesDetail extracts the "supported_languages" field from the "common" section of the output of
+// AppInfoPrint, and returns the LanguageSupport for each language. The keys of the returned map are lowercase language
+// names.
+//
+// The field can either be a map of language to a map containing the "supported", "full_audio", and "subtitles" flags,
+// or a comma/pipe-separated HTML-annotated string like the one on the store page (i.e. "English<strong>*</strong>,
+// French"). In the latter format, each listed language supports the interface and subtitles, and languages marked with
+// an asterisk also have full audio. If the field cannot be found then ErrFieldNotFound is returned.
+func AppSupportedLanguagesDetail(output map[string]any) (map[string]LanguageSupport, error) {
+	value, err := GetNestedValue(output, "common.supported_languages")
+	if err != nil {
+		return nil, err
+	}
+
+	languages := make(map[string]LanguageSupport)
+	switch v := value.(type) {
+	case map[string]any:
+		for language, supportValue := range v {
+			support, ok := supportValue.(map[string]any)
+			if !ok {
+				return nil, errors.Errorf(
+					"support for language %q is not a map, it is a %T",
+					language, supportValue,
+				)
+			}
+
+			flag := func(key string) bool {
+				value, ok := support[key]
+				return ok && (toString(value) == "true" || toString(value) == "1")
+			}
+			languages[strings.ToLower(strings.TrimSpace(language))] = LanguageSupport{
+				Interface: flag("supported"),
+				FullAudio: flag("full_audio"),
+				Subtitles: flag("subtitles"),
+			}
+		}
+	default:
+		supportedLanguages := supportedLanguagesFootnotePattern.ReplaceAllString(toString(v), "")
+		for _, language := range strings.FieldsFunc(supportedLanguages, func(r rune) bool { return r == ',' || r == '|' }) {
+			fullAudio := supportedLanguagesFullAudioPattern.MatchString(language)
+			language = supportedLanguagesFullAudioPattern.ReplaceAllString(language, "")
+			language = html.UnescapeString(supportedLanguagesTagPattern.ReplaceAllString(language, ""))
+			if language = strings.ToLower(strings.TrimSpace(language)); language != "" {
+				languages[language] = LanguageSupport{Interface: true, FullAudio: fullAudio, Subtitles: true}
+			}
+		}
+	}
+	return languages, nil
+}
+
+// AppShortDescription extracts the "short_description" field from the "common" section of the output of AppInfoPrint.
+// Any HTML entities within the description are unescaped. If the field cannot be found then ErrFieldNotFound is
+// returned.
+func AppShortDescription(output map[string]any) (string, error) {
+	description, err := getNestedString(output, "common.short_description")
+	if err != nil {
+		return "", err
+	}
+	return html.UnescapeString(description), nil
+}
+
+// fullDescriptionPaths are the paths that the full description of an app can be found at, in order of preference.
+var fullDescriptionPaths = []string{"common.long_description", "common.detailed_description", "common.about_the_game"}
+
+// AppFullDescription extracts the full description of the app from the "common" section of the output of
+// AppInfoPrint. The "long_description", "detailed_description", and "about_the_game" fields are checked in that order.
+// Any HTML entities within the description are unescaped. If none of the fields can be found then ErrFieldNotFound is
+// returned.
+func AppFullDescription(output map[string]any) (string, error) {
+	for _, path := range fullDescriptionPaths {
+		if description, err := getNestedString(output, path); err == nil {
+			return html.UnescapeString(description), nil
+		}
+	}
+	return "", errors.Wrap(ErrFieldNotFound, "cannot find a full description")
+}
+
+// Category is a Steam category (i.e. single-player, multi-player) that an app belongs to.
+type Category struct {
+	ID          int
+	Description string
+}
+
+// The IDs of the categories that Steam uses.
+const (
+	CategoryMultiPlayer              = 1
+	CategorySinglePlayer             = 2
+	CategoryCoOp                     = 9
+	CategoryPartialControllerSupport = 18
+	CategoryMMO                      = 20
+	CategorySteamAchievements        = 22
+	CategorySteamCloud               = 23
+	CategorySharedSplitScreen        = 24
+	CategorySteamLeaderboards        = 25
+	CategoryCrossPlatformMultiplayer = 27
+	CategoryFullControllerSupport    = 28
+	CategorySteamTradingCards        = 29
+	CategorySteamWorkshop            = 30
+	CategoryInAppPurchases           = 35
+	CategoryOnlinePvP                = 36
+	CategorySharedSplitScreenPvP     = 37
+	CategoryOnlineCoOp               = 38
+	CategorySharedSplitScreenCoOp    = 39
+	CategoryRemotePlayOnTV           = 41
+	CategoryRemotePlayTogether       = 44
+	CategoryPvP                      = 49
+	CategoryEarlyAccess              = 70
+	CategoryVRHTCVive                = 101
+	CategoryVROculusRift             = 102
+	CategoryVRWindowsMixedReality    = 104
+	CategoryVRSupported              = 401
+)
+
+// categoryDescriptions are the descriptions of the known categories. These are used when the output of AppInfoPrint
+// does not contain a description.
+var categoryDescriptions = map[int]string{
+	CategoryMultiPlayer:              "Multi-player",
+	CategorySinglePlayer:             "Single-player",
+	CategoryCoOp:                     "Co-op",
+	CategoryPartialControllerSupport: "Partial Controller Support",
+	CategoryMMO:                      "MMO",
+	CategorySteamAchievements:        "Steam Achievements",
+	CategorySteamCloud:               "Steam Cloud",
+	CategorySharedSplitScreen:        "Shared/Split Screen",
+	CategorySteamLeaderboards:        "Steam Leaderboards",
+	CategoryCrossPlatformMultiplayer: "Cross-Platform Multiplayer",
+	CategoryFullControllerSupport:    "Full controller support",
+	CategorySteamTradingCards:        "Steam Trading Cards",
+	CategorySteamWorkshop:            "Steam Workshop",
+	CategoryInAppPurchases:           "In-App Purchases",
+	CategoryOnlinePvP:                "Online PvP",
+	CategorySharedSplitScreenPvP:     "Shared/Split Screen PvP",
+	CategoryOnlineCoOp:               "Online Co-op",
+	CategorySharedSplitScreenCoOp:    "Shared/Split Screen Co-op",
+	CategoryRemotePlayOnTV:           "Remote Play on TV",
+	CategoryRemotePlayTogether:       "Remote Play Together",
+	CategoryPvP:                      "PvP",
+	CategoryEarlyAccess:              "Early Access",
+	CategoryVRHTCVive:                "HTC Vive",
+	CategoryVROculusRift:             "Oculus Rift",
+	CategoryVRWindowsMixedReality:    "Windows Mixed Reality",
+	CategoryVRSupported:              "VR Supported",
+}
+
+// multiplayerCategories are the categories that indicate that an app is multiplayer.
+var multiplayerCategories = []int{
+	CategoryMultiPlayer,
+	CategoryCoOp,
+	CategoryMMO,
+	CategoryCrossPlatformMultiplayer,
+	CategoryOnlinePvP,
+	CategorySharedSplitScreenPvP,
+	CategoryOnlineCoOp,
+	CategorySharedSplitScreenCoOp,
+	CategoryPvP,
+}
+
+// AppCategories extracts the "category" section from the "common" section of the output of AppInfoPrint. This section
+// is usually a map of "category_<ID>" to "1", but it can also be a map of index to category, where each category is
+// either a map containing an "id" and a "description", or just the ID of the category. The categories are returned
+// sorted by their ID. If the section cannot be found then ErrFieldNotFound is returned.
+func AppCategories(output map[string]any) ([]Category, error) {
+	value, err := GetNestedValue(output, "common.category")
+	if err != nil {
+		return nil, err
+	}
+
+	categoriesMap, ok := value.(map[string]any)
+	if !ok {
+		return nil, errors.Errorf("category is not a map, it is a %T", value)
+	}
+
+	categories := make([]Category, 0, len(categoriesMap))
+	for key, categoryValue := range categoriesMap {
+		var (
+			id          any
+			description string
+		)
+
+		switch c := categoryValue.(type) {
+		case map[string]any:
+			if id, ok = c["id"]; !ok {
+				return nil, errors.Wrapf(ErrFieldNotFound, "category at index %s does not have an id", key)
+			}
+			if descriptionValue, ok := c["description"]; ok {
+				description = toString(descriptionValue)
+			}
+		default:
+			if strings.HasPrefix(key, "category_") {
+				// Categories that are explicitly disabled are skipped
+				if toString(c) == "0" {
+					continue
+				}
+				id = strings.TrimPrefix(key, "category_")
+			} else {
+				id = c
+			}
+		}
+
+		id64, err := toInt64(id)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not parse category ID for %s", key)
+		}
+
+		if description == "" {
+			description = categoryDescriptions[int(id64)]
+		}
+		categories = append(categories, Category{ID: int(id64), Description: description})
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].ID < categories[j].ID
+	})
+	return categories, nil
+}
+
+// appHasAnyCategory checks whether the output of AppInfoPrint has any of the categories with the given IDs.
+func appHasAnyCategory(output map[string]any, ids ...int) bool {
+	categories, _ := AppCategories(output)
+	for _, category := range categories {
+		for _, id := range ids {
+			if category.ID == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AppIsMultiplayer checks whether the output of AppInfoPrint has any multiplayer category. I.e. CategoryMultiPlayer,
+// CategoryCoOp, CategoryOnlinePvP.
+func AppIsMultiplayer(output map[string]any) bool {
+	return appHasAnyCategory(output, multiplayerCategories...)
+}
+
+// AppIsSingleplayer checks whether the output of AppInfoPrint has the CategorySinglePlayer category.
+func AppIsSingleplayer(output map[string]any) bool {
+	return appHasAnyCategory(output, CategorySinglePlayer)
+}
+
+// earlyAccessGameIDPaths are the paths that the "early_access_gameid" field can be found at.
+var earlyAccessGameIDPaths = []string{"common.early_access_gameid", "extended.early_access_gameid"}
+
+// AppIsEarlyAccess checks whether the output of AppInfoPrint has the CategoryEarlyAccess category, or a non-zero
+// "early_access_gameid" field within the "common" or "extended" sections.
+func AppIsEarlyAccess(output map[string]any) bool {
+	if appHasAnyCategory(output, CategoryEarlyAccess) {
+		return true
+	}
+
+	for _, path := range earlyAccessGameIDPaths {
+		if gameID, err := getNestedString(output, path); err == nil && gameID != "" && gameID != "0" {
+			return true
+		}
+	}
+	return false
+}
+
+// The VR platforms that are returned by AppVRPlatforms.
+const (
+	VRPlatformOpenVR    = "OpenVR"
+	VRPlatformOculus    = "Oculus"
+	VRPlatformWindowsMR = "WindowsMR"
+)
+
+// vrPlatformCategories are the IDs of the categories that indicate support for each VR platform, in the order that
+// they are returned by AppVRPlatforms.
+var vrPlatformCategories = []struct {
+	platform string
+	id       int
+}{
+	{VRPlatformOpenVR, CategoryVRHTCVive},
+	{VRPlatformOculus, CategoryVROculusRift},
+	{VRPlatformWindowsMR, CategoryVRWindowsMixedReality},
+}
+
+// AppIsVROnly checks whether the "vronly" field within the "common" section of the output of AppInfoPrint is set to
+// "1". False is returned if the field cannot be found.
+func AppIsVROnly(output map[string]any) bool {
+	vrOnly, err := getNestedString(output, "common.vronly")
+	return err == nil && vrOnly == "1"
+}
+
+// AppIsVRSupported checks whether the output of AppInfoPrint has the CategoryVRSupported category, or an "openvr"
+// section (see AppSupportsVR). Apps that are AppIsVROnly are also VR supported.
+func AppIsVRSupported(output map[string]any) bool {
+	return appHasAnyCategory(output, CategoryVRSupported) || AppSupportsVR(output) || AppIsVROnly(output)
+}
+
+// AppVRPlatforms returns the VR platforms that the app supports, using the categories of the output of AppInfoPrint.
+// The returned platforms will be any of VRPlatformOpenVR, VRPlatformOculus, and VRPlatformWindowsMR, in that order.
+func AppVRPlatforms(output map[string]any) []string {
+	platforms := make([]string, 0)
+	for _, platformCategory := range vrPlatformCategories {
+		if appHasAnyCategory(output, platformCategory.id) {
+			platforms = append(platforms, platformCategory.platform)
+		}
+	}
+	return platforms
+}
+
+// AppHasWorkshop checks whether the output of AppInfoPrint has the CategorySteamWorkshop category.
+func AppHasWorkshop(output map[string]any) bool {
+	return appHasAnyCategory(output, CategorySteamWorkshop)
+}
+
+// AppWorkshopVisible checks whether the "workshop_visible" field within the "extended" section of the output of
+// AppInfoPrint is set. False is returned if the field cannot be found.
+func AppWorkshopVisible(output map[string]any) bool {
+	visible, err := AppExtendedBoolField(output, "workshop_visible")
+	return err == nil && visible
+}
+
+// SteamCommunityAppBaseURL is the base URL of the community hubs of apps on the Steam community.
+const SteamCommunityAppBaseURL = "https://steamcommunity.com/app"
+
+// SteamStoreAppBaseURL is the base URL of the store pages of apps on the Steam store.
+const SteamStoreAppBaseURL = "https://store.steampowered.com/app"
+
+// CommunityHub contains the URLs of the community hub of an app that has a "community_hub" section in the output of
+// AppInfoPrint.
+type CommunityHub struct {
+	// DiscussionURL is the URL of the discussion boards of the app. I.e.
+	// "<SteamCommunityAppBaseURL>/<appID>/discussions/".
+	DiscussionURL string
+	// WorkshopURL is the URL of the Steam Workshop of the app. I.e. "<SteamCommunityAppBaseURL>/<appID>/workshop/". This
+	// is only set when the app has the CategorySteamWorkshop category, or when its workshop is visible (see
+	// AppWorkshopVisible).
+	WorkshopURL string
+	// TagStore is the URL of the store page of the app, where the tags applied to it by users are listed. I.e.
+	// "<SteamStoreAppBaseURL>/<appID>/".
+	TagStore string
+}
+
+// appCommunityHubPaths are the paths that the "community_hub" section of the output of AppInfoPrint can be found at.
+var appCommunityHubPaths = []string{"community_hub", "common.community_hub"}
+
+// AppHasCommunityHub checks whether the output of AppInfoPrint has a "community_hub" section.
+func AppHasCommunityHub(output map[string]any) bool {
+	for _, path := range appCommunityHubPaths {
+		if _, err := GetNestedValue(output, path); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// AppCommunityHub constructs the CommunityHub of the app using the "appid" of the output of AppInfoPrint. If the output
+// has no "community_hub" section (see AppHasCommunityHub), or no "appid", then ErrFieldNotFound is returned.
+func AppCommunityHub(output map[string]any) (*CommunityHub, error) {
+	if !AppHasCommunityHub(output) {
+		return nil, errors.Wrap(ErrFieldNotFound, "cannot find a community_hub section")
+	}
+
+	appID, err := AppID(output)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot construct community hub URLs without an appid")
+	}
+
+	hub := &CommunityHub{
+		DiscussionURL: fmt.Sprintf("%s/%d/discussions/", SteamCommunityAppBaseURL, appID),
+		TagStore:      fmt.Sprintf("%s/%d/", SteamStoreAppBaseURL, appID),
+	}
+	if AppHasWorkshop(output) || AppWorkshopVisible(output) {
+		hub.WorkshopURL = fmt.Sprintf("%s/%d/workshop/", SteamCommunityAppBaseURL, appID)
+	}
+	return hub, nil
+}
+
+// DeckCompat represents the Steam Deck compatibility category of an app.
+type DeckCompat int
+
+const (
+	// DeckCompatUnknown is for apps that have not been tested on the Steam Deck.
+	DeckCompatUnknown DeckCompat = iota
+	// DeckCompatUnsupported is for apps that do not work on the Steam Deck.
+	DeckCompatUnsupported
+	// DeckCompatPlayable is for apps that work on the Steam Deck, but might require extra effort to play.
+	DeckCompatPlayable
+	// DeckCompatVerified is for apps that work great on the Steam Deck.
+	DeckCompatVerified
+)
+
+// String returns the name of the DeckCompat that is displayed on the Steam store.
+func (dc DeckCompat) String() string {
+	switch dc {
+	case DeckCompatUnknown:
+		return "Unknown"
+	case DeckCompatUnsupported:
+		return "Unsupported"
+	case DeckCompatPlayable:
+		return "Playable"
+	case DeckCompatVerified:
+		return "Verified"
+	default:
+		return "<nil>"
+	}
+}
+
+// deckCompatPaths are the paths that the Steam Deck compatibility category can be found at.
+var deckCompatPaths = []string{"common.steam_deck_compat.category", "common.steam_deck_compatibility.category"}
+
+// AppSteamDeckCompat extracts the Steam Deck compatibility "category" from the "steam_deck_compat" (or
+// "steam_deck_compatibility") section of the "common" section of the output of AppInfoPrint. If the category cannot be
+// found then ErrFieldNotFound is returned.
+func AppSteamDeckCompat(output map[string]any) (DeckCompat, error) {
+	for _, path := range deckCompatPaths {
+		if _, err := GetNestedValue(output, path); err != nil {
+			continue
+		}
+
+		category, err := getNestedInt64(output, path)
+		if err != nil {
+			return DeckCompatUnknown, errors.Wrapf(err, "could not parse %s", path)
+		}
+		return DeckCompat(category), nil
+	}
+	return DeckCompatUnknown, errors.Wrap(ErrFieldNotFound, "cannot find a Steam Deck compatibility category")
+}
+
+// AppIsSteamDeckVerified checks whether the output of AppInfoPrint has the DeckCompatVerified Steam Deck compatibility
+// category.
+func AppIsSteamDeckVerified(output map[string]any) bool {
+	compat, err := AppSteamDeckCompat(output)
+	return err == nil && compat == DeckCompatVerified
+}
+
+// ControllerType represents the type of controller that an app natively supports.
+type ControllerType int
+
+const (
+	// ControllerTypeNone is for apps that do not support controllers.
+	ControllerTypeNone ControllerType = iota
+	// ControllerTypeGeneric is for apps that support generic controllers through Steam Input.
+	ControllerTypeGeneric
+	// ControllerTypeSteamController is for apps that natively support the Steam Controller.
+	ControllerTypeSteamController
+	// ControllerTypeDualShock is for apps that natively support DualShock controllers.
+	ControllerTypeDualShock
+)
+
+// String returns the name of the ControllerType.
+func (ct ControllerType) String() string {
+	switch ct {
+	case ControllerTypeNone:
+		return "None"
+	case ControllerTypeGeneric:
+		return "Generic"
+	case ControllerTypeSteamController:
+		return "Steam Controller"
+	case ControllerTypeDualShock:
+		return "DualShock"
+	default:
+		return "<nil>"
+	}
+}
+
+// AppControllerType extracts the "controllertype" field from the "common" section of the output of AppInfoPrint. The
+// value is an integer that can be converted to a ControllerType. If the field cannot be found then ErrFieldNotFound is
+// returned.
+func AppControllerType(output map[string]any) (string, error) {
+	return getNestedString(output, "common.controllertype")
+}
+
+// AppHasNativeControllerSupport checks whether the "controllertype" field within the "common" section of the output of
+// AppInfoPrint is a ControllerType other than ControllerTypeNone and ControllerTypeGeneric. False is returned if the
+// field cannot be found.
+func AppHasNativeControllerSupport(output map[string]any) bool {
+	value, err := AppControllerType(output)
+	if err != nil {
+		return false
+	}
+
+	controllerType, err := strconv.Atoi(value)
+	return err == nil && ControllerType(controllerType) != ControllerTypeNone &&
+		ControllerType(controllerType) != ControllerTypeGeneric
+}
+
+// InputFlags is a bitmask of the input types that an app natively supports, as found in the "supported_input" field of
+// the output of AppInfoPrint.
+type InputFlags uint32
+
+const (
+	// InputFlagKeyboardMouse is set for apps that natively support keyboard and mouse.
+	InputFlagKeyboardMouse InputFlags = 1 << iota
+	// InputFlagGamepad is set for apps that natively support gamepads.
+	InputFlagGamepad
+	// InputFlagSteamController is set for apps that natively support the Steam Controller.
+	InputFlagSteamController
+)
+
+// inputFlagNames are the names of each of the InputFlags, in the order that they are returned by InputFlags.String.
+var inputFlagNames = []struct {
+	flag InputFlags
+	name string
+}{
+	{InputFlagKeyboardMouse, "Keyboard/Mouse"},
+	{InputFlagGamepad, "Gamepad"},
+	{InputFlagSteamController, "Steam Controller"},
+}
+
+// Has checks whether all the bits of the given InputFlags are set.
+func (f InputFlags) Has(flag InputFlags) bool {
+	return f&flag == flag
+}
+
+// String returns the names of each of the set InputFlags, separated by "|". I.e. "Keyboard/Mouse|Gamepad". "None" is
+// returned if no flags are set, and any unknown bits are written in hexadecimal.
+func (f InputFlags) String() string {
+	if f == 0 {
+		return "None"
+	}
+
+	names := make([]string, 0, len(inputFlagNames))
+	remaining := f
+	for _, flagName := range inputFlagNames {
+		if f.Has(flagName.flag) {
+			names = append(names, flagName.name)
+			remaining &^= flagName.flag
+		}
+	}
+
+	if remaining != 0 {
+		names = append(names, fmt.Sprintf("0x%x", uint32(remaining)))
+	}
+	return strings.Join(names, "|")
+}
+
+// AppSupportedInput extracts the "supported_input" bitmask from the "common" section of the output of AppInfoPrint. If
+// the field cannot be found then ErrFieldNotFound is returned.
+func AppSupportedInput(output map[string]any) (InputFlags, error) {
+	value, err := getNestedInt64(output, "common.supported_input")
+	if err != nil {
+		return 0, err
+	}
+
+	if value < 0 || value > math.MaxUint32 {
+		return 0, errors.Errorf("supported_input %d is not a valid bitmask", value)
+	}
+	return InputFlags(value), nil
+}
+
+// Association is an association between an app and a company. I.e. the developer or publisher of the app.
+type Association struct {
+	Name string
+	// Type is the type of the association. I.e. "developer", "publisher", "franchise".
+	Type string
+}
+
+// associationsPaths are the paths that the "associations" section can be found at.
+var associationsPaths = []string{"common.associations", "associations"}
+
+// AppAssociations extracts the "associations" section of the output of AppInfoPrint. This section is a map of index
+// to a map containing a "name" and a "type". The "common" section, as well as the root of the output are checked for
+// the "associations" section. If it cannot be found then we will fall back to the legacy "developer" and "publisher"
+// fields in the "common" section, which can either be a string or a map of index to name. The associations are
+// returned in index order. If none of these can be found then ErrFieldNotFound is returned.
+func AppAssociations(output map[string]any) ([]Association, error) {
+	for _, path := range associationsPaths {
+		value, err := GetNestedValue(output, path)
+		if err != nil {
+			continue
+		}
+
+		associationsMap, ok := value.(map[string]any)
+		if !ok {
+			return nil, errors.Errorf("associations is not a map, it is a %T", value)
+		}
+
+		indices := make([]string, 0, len(associationsMap))
+		for index := range associationsMap {
+			indices = append(indices, index)
+		}
+		sort.Slice(indices, func(i, j int) bool {
+			return lessNumeric(indices[i], indices[j])
+		})
+
+		associations := make([]Association, 0, len(indices))
+		for _, index := range indices {
+			association, ok := associationsMap[index].(map[string]any)
+			if !ok {
+				return nil, errors.Errorf(
+					"association at index %s is not a map, it is a %T",
+					index, associationsMap[index],
+				)
+			}
+
+			var a Association
+			if name, ok := association["name"]; ok {
+				a.Name = toString(name)
+			}
+			if associationType, ok := association["type"]; ok {
+				a.Type = toString(associationType)
+			}
+			associations = append(associations, a)
+		}
+		return associations, nil
+	}
+
+	// Fall back to the legacy fields
+	associations := make([]Association, 0)
+	for _, associationType := range []string{"developer", "publisher"} {
+		value, err := GetNestedValue(output, "common."+associationType)
+		if err != nil {
+			continue
+		}
+
+		var names []string
+		if namesMap, ok := value.(map[string]any); ok {
+			for index := range namesMap {
+				names = append(names, index)
+			}
+			sort.Slice(names, func(i, j int) bool {
+				return lessNumeric(names[i], names[j])
+			})
+			for i, index := range names {
+				names[i] = toString(namesMap[index])
+			}
+		} else {
+			names = []string{toString(value)}
+		}
+
+		for _, name := range names {
+			associations = append(associations, Association{Name: name, Type: associationType})
+		}
+	}
+
+	if len(associations) == 0 {
+		return nil, errors.Wrap(ErrFieldNotFound, "cannot find associations, or a developer or publisher")
+	}
+	return associations, nil
+}
+
+// appAssociationNames returns the names of the associations of the given type from AppAssociations.
+func appAssociationNames(output map[string]any, associationType string) []string {
+	associations, _ := AppAssociations(output)
+	names := make([]string, 0)
+	for _, association := range associations {
+		if strings.EqualFold(association.Type, associationType) {
+			names = append(names, association.Name)
+		}
+	}
+	return names
+}
+
+// AppDevelopers returns the names of the developers of the app from AppAssociations.
+func AppDevelopers(output map[string]any) []string {
+	return appAssociationNames(output, "developer")
+}
+
+// AppPublishers returns the names of the publishers of the app from AppAssociations.
+func AppPublishers(output map[string]any) []string {
+	return appAssociationNames(output, "publisher")
+}
+
+// websitePaths are the paths that the website of an app can be found at, in order of preference.
+var websitePaths = []string{"common.homepage", "common.website"}
+
+// AppWebsite extracts the website of the app from the "common" section of the output of AppInfoPrint. The "homepage"
+// and "website" fields are checked in that order, and the first non-empty value is returned. If neither of the fields
+// can be found then ErrFieldNotFound is returned.
+func AppWebsite(output map[string]any) (string, error) {
+	for _, path := range websitePaths {
+		if website, err := getNestedString(output, path); err == nil && website != "" {
+			return website, nil
+		}
+	}
+	return "", errors.Wrap(ErrFieldNotFound, "cannot find a homepage or website")
+}
+
+// AppSupportURL extracts the "url" field from the "support_info" section of the output of AppInfoPrint. The "common"
+// section, as well as the root of the output are checked for the "support_info" section. If the field cannot be found
+// then ErrFieldNotFound is returned.
+func AppSupportURL(output map[string]any) (string, error) {
+	for _, path := range []string{"common.support_info.url", "support_info.url"} {
+		if url, err := getNestedString(output, path); err == nil && url != "" {
+			return url, nil
+		}
+	}
+	return "", errors.Wrap(ErrFieldNotFound, "cannot find a support_info url")
+}
+
+// AppSteamControllerConfigURL extracts the "steam_controller_support_url" field from the "common" section of the output
+// of AppInfoPrint. This is the URL of the Steam Input configuration for the app. If the field cannot be found then
+// ErrFieldNotFound is returned.
+func AppSteamControllerConfigURL(output map[string]any) (string, error) {
+	return getNestedString(output, "common.steam_controller_support_url")
+}
+
+// CoerceNumericStrings returns a copy of the given parsed output of AppInfoPrint, where each string value that
+// ParseArgType identifies as a Number is converted to an int64 or a float64. Nested maps are converted recursively.
+// Integers that are too large to fit in an int64 (i.e. manifest IDs), as well as "inf" and "nan" values, are left as
+// strings so that no information is lost. This is applied automatically when a SteamCMD is created using
+// WithNumericCoercion.
+func CoerceNumericStrings(m map[string]any) map[string]any {
+	coerced := make(map[string]any, len(m))
+	for key, value := range m {
+		switch v := value.(type) {
+		case map[string]any:
+			coerced[key] = CoerceNumericStrings(v)
+		case string:
+			coerced[key] = coerceNumericString(v)
+		default:
+			coerced[key] = v
+		}
+	}
+	return coerced
+}
+
+// coerceNumericString converts the given string to an int64 or a float64 using ParseArgType, if this can be done
+// without losing any information.
+func coerceNumericString(s string) any {
+	value, argType := ParseArgType(strings.TrimSpace(s))
+	if argType != Number {
+		return s
+	}
+
+	if f, ok := value.(float64); ok {
+		// Integers that overflow int64 are parsed as floats, and ParseFloat will also parse "inf" and "nan"
+		if math.IsInf(f, 0) || math.IsNaN(f) || !strings.ContainsAny(s, ".eE") {
+			return s
+		}
+	}
+	return value
+}
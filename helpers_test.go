@@ -0,0 +1,1313 @@
+package steamcmd
+
+import (
+	"fmt"
+	"github.com/pkg/errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestAppReviewScore(t *testing.T) {
+	for testNo, test := range []struct {
+		output        map[string]any
+		expectedScore int
+		expectedDesc  string
+		expectedErr   error
+		anyErr        bool
+	}{
+		{
+			output: map[string]any{"common": map[string]any{
+				"review_score":      "9",
+				"review_score_desc": "Overwhelmingly Positive",
+			}},
+			expectedScore: int(ReviewOverwhelminglyPositive),
+			expectedDesc:  "Overwhelmingly Positive",
+		},
+		{
+			output: map[string]any{"common": map[string]any{
+				"review_score":      5,
+				"review_score_desc": "Mixed",
+			}},
+			expectedScore: int(ReviewMixed),
+			expectedDesc:  "Mixed",
+		},
+		{
+			output: map[string]any{"common": map[string]any{
+				"review_score":      int64(1),
+				"review_score_desc": "Overwhelmingly Negative",
+			}},
+			expectedScore: int(ReviewOverwhelminglyNegative),
+			expectedDesc:  "Overwhelmingly Negative",
+		},
+		{
+			output:      map[string]any{"common": map[string]any{"review_score_desc": "Mixed"}},
+			expectedErr: ErrFieldNotFound,
+		},
+		{
+			output:      map[string]any{"common": map[string]any{"review_score": "5"}},
+			expectedErr: ErrFieldNotFound,
+		},
+		{
+			output:      map[string]any{},
+			expectedErr: ErrFieldNotFound,
+		},
+		{
+			output: map[string]any{"common": map[string]any{
+				"review_score":      "not a number",
+				"review_score_desc": "Mixed",
+			}},
+			anyErr: true,
+		},
+	} {
+		score, desc, err := AppReviewScore(test.output)
+		switch {
+		case test.expectedErr != nil:
+			if !errors.Is(err, test.expectedErr) {
+				t.Errorf("%d: expected error %v, got %v", testNo+1, test.expectedErr, err)
+			}
+		case test.anyErr:
+			if err == nil {
+				t.Errorf("%d: expected an error, got nil", testNo+1)
+			}
+		case err != nil:
+			t.Errorf("%d: unexpected error: %v", testNo+1, err)
+		}
+
+		if score != test.expectedScore || desc != test.expectedDesc {
+			t.Errorf(
+				"%d: expected (%d, %q), got (%d, %q)",
+				testNo+1, test.expectedScore, test.expectedDesc, score, desc,
+			)
+		}
+	}
+}
+
+func ExampleAppMetacritic() {
+	output := map[string]any{"common": map[string]any{
+		"metacritic_score":   "75",
+		"metacritic_fullurl": "https://www.metacritic.com/game/pc/human-fall-flat",
+	}}
+	fmt.Println(AppMetacritic(output))
+	fmt.Println(AppHasMetacritic(output))
+	fmt.Println(AppHasMetacritic(map[string]any{"common": map[string]any{"metacritic_score": "75"}}))
+	// Output:
+	// 75 https://www.metacritic.com/game/pc/human-fall-flat <nil>
+	// true
+	// false
+}
+
+func ExampleAppReleaseDateWithConfidence() {
+	fmt.Println(AppReleaseDateWithConfidence(map[string]any{"common": map[string]any{
+		"release_date": map[string]any{"date": "8 Oct, 2019"},
+	}}))
+	fmt.Println(AppReleaseDateWithConfidence(map[string]any{"common": map[string]any{
+		"release_date": "Q2 2021",
+	}}))
+	// Output:
+	// 2019-10-08 00:00:00 +0000 UTC Day 8 Oct, 2019 <nil>
+	// 2021-04-01 00:00:00 +0000 UTC Quarter Q2 2021 <nil>
+}
+
+func TestAppGOOS(t *testing.T) {
+	for testNo, test := range []struct {
+		oslist   any
+		expected []string
+	}{
+		{"windows,macos,linux", []string{"windows", "darwin", "linux"}},
+		{"windows", []string{"windows"}},
+		{"macos", []string{"darwin"}},
+		{"linux", []string{"linux"}},
+		{"windows, amiga ,linux", []string{"windows", "linux"}},
+		{"", []string{}},
+		{nil, []string{}},
+	} {
+		output := map[string]any{"common": map[string]any{}}
+		if test.oslist != nil {
+			output["common"].(map[string]any)["oslist"] = test.oslist
+		}
+
+		if actual := AppGOOS(output); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%d: expected %v, got %v", testNo+1, test.expected, actual)
+		}
+	}
+}
+
+func TestGOOSToSteamOS(t *testing.T) {
+	for testNo, test := range []struct {
+		goos        string
+		expected    string
+		expectedErr bool
+	}{
+		{"windows", "windows", false},
+		{"darwin", "macos", false},
+		{"linux", "linux", false},
+		{"plan9", "", true},
+	} {
+		actual, err := GOOSToSteamOS(test.goos)
+		if (err != nil) != test.expectedErr {
+			t.Errorf("%d: expected error = %t, got %v", testNo+1, test.expectedErr, err)
+		}
+
+		if actual != test.expected {
+			t.Errorf("%d: expected %q, got %q", testNo+1, test.expected, actual)
+		}
+	}
+}
+
+func ExampleAppOriginalPrice() {
+	output := map[string]any{
+		"common": map[string]any{
+			"price_overview": map[string]any{"currency": "GBP", "initial": "1499", "final": "749"},
+		},
+		"extended": map[string]any{"isfreeapp": "0"},
+	}
+	fmt.Println(AppOriginalPrice(output))
+	fmt.Println(AppIsFree(output))
+	fmt.Println(AppIsFree(map[string]any{"extended": map[string]any{"isfreeapp": "1"}}))
+	// Output:
+	// 1499 GBP <nil>
+	// false
+	// true
+}
+
+func ExampleAppName() {
+	output := map[string]any{
+		"appid": "477160",
+		"common": map[string]any{
+			"name": "Human: Fall Flat",
+			"type": "Game",
+		},
+	}
+	fmt.Println(AppID(output))
+	fmt.Println(AppName(output))
+	fmt.Println(AppType(output))
+	_, err := AppName(map[string]any{"common": map[string]any{}})
+	fmt.Println(errors.Is(err, ErrFieldNotFound))
+	// Output:
+	// 477160 <nil>
+	// Human: Fall Flat <nil>
+	// Game <nil>
+	// true
+}
+
+func TestAppDeveloperPublisher(t *testing.T) {
+	for testNo, test := range []struct {
+		common            map[string]any
+		expectedDeveloper string
+		expectedPublisher string
+		expectedErr       bool
+	}{
+		{
+			common: map[string]any{
+				"developer": "No Brakes Games",
+				"publisher": "Curve Games",
+			},
+			expectedDeveloper: "No Brakes Games",
+			expectedPublisher: "Curve Games",
+		},
+		{
+			common: map[string]any{
+				"developer": map[string]any{"0": "No Brakes Games", "1": "Another Developer"},
+				"publisher": map[string]any{"0": "Curve Games"},
+			},
+			expectedDeveloper: "No Brakes Games",
+			expectedPublisher: "Curve Games",
+		},
+		{
+			common: map[string]any{
+				"developer": map[string]any{"1": "Another Developer"},
+				"publisher": map[string]any{},
+			},
+			expectedErr: true,
+		},
+		{
+			common:      map[string]any{},
+			expectedErr: true,
+		},
+	} {
+		output := map[string]any{"common": test.common}
+		developer, devErr := AppDeveloper(output)
+		publisher, pubErr := AppPublisher(output)
+		if test.expectedErr {
+			if !errors.Is(devErr, ErrFieldNotFound) || !errors.Is(pubErr, ErrFieldNotFound) {
+				t.Errorf("%d: expected ErrFieldNotFound, got %v and %v", testNo+1, devErr, pubErr)
+			}
+			continue
+		}
+
+		if devErr != nil || pubErr != nil {
+			t.Errorf("%d: unexpected errors: %v and %v", testNo+1, devErr, pubErr)
+		}
+
+		if developer != test.expectedDeveloper || publisher != test.expectedPublisher {
+			t.Errorf(
+				"%d: expected (%q, %q), got (%q, %q)",
+				testNo+1, test.expectedDeveloper, test.expectedPublisher, developer, publisher,
+			)
+		}
+	}
+}
+
+func ExampleAppParentID() {
+	dlc := map[string]any{"extended": map[string]any{"dlcforappid": "477160"}}
+	game := map[string]any{"extended": map[string]any{"isfreeapp": "0"}}
+	fmt.Println(AppParentID(dlc))
+	fmt.Println(AppIsDLC(dlc), AppIsDLCFor(dlc, 477160), AppIsDLCFor(dlc, 620))
+	_, err := AppParentID(game)
+	fmt.Println(errors.Is(err, ErrFieldNotFound), AppIsDLC(game))
+	// Output:
+	// 477160 <nil>
+	// true true false
+	// true false
+}
+
+func ExampleAppDemoOfAppID() {
+	demo := map[string]any{"extended": map[string]any{"demo_of_appid": "620"}}
+	game := map[string]any{"extended": map[string]any{"isfreeapp": "0"}}
+	fmt.Println(AppDemoOfAppID(demo))
+	fmt.Println(AppIsDemo(demo), AppIsDemoFor(demo, 620), AppIsDemoFor(demo, 477160))
+	_, err := AppDemoOfAppID(game)
+	fmt.Println(errors.Is(err, ErrFieldNotFound), AppIsDemo(game))
+	// Output:
+	// 620 <nil>
+	// true true false
+	// true false
+}
+
+func ExampleAppDLCType() {
+	output := map[string]any{"extended": map[string]any{
+		"listofdlc":    "1016620,1016621,1016622",
+		"optional_dlc": "1016622",
+	}}
+	fmt.Println(AppOptionalDLC(output))
+	fmt.Println(AppDLCType(output, 1016620))
+	fmt.Println(AppDLCType(output, 1016622))
+	_, err := AppDLCType(output, 620)
+	fmt.Println(errors.Is(err, ErrFieldNotFound))
+	// Output:
+	// [1016622] <nil>
+	// required <nil>
+	// optional <nil>
+	// true
+}
+
+func ExampleAppGenres() {
+	output := map[string]any{"common": map[string]any{
+		"genres": map[string]any{
+			"0": map[string]any{"id": "23", "description": "Indie"},
+			"1": map[string]any{"id": "1", "description": "Action"},
+			"2": map[string]any{"id": "25", "description": "Adventure"},
+		},
+	}}
+	fmt.Println(AppGenres(output))
+	fmt.Println(AppHasGenre(output, 25))
+	fmt.Println(AppHasGenre(output, 2))
+	fmt.Println(AppGenres(map[string]any{"common": map[string]any{
+		"genres": map[string]any{"0": "25", "1": "1", "2": "999"},
+	}}))
+	// Output:
+	// [{1 Action} {23 Indie} {25 Adventure}] <nil>
+	// true
+	// false
+	// [{1 Action} {25 Adventure} {999 }] <nil>
+}
+
+func ExampleAppPrimaryGenre() {
+	fmt.Println(AppPrimaryGenre(map[string]any{"common": map[string]any{"primary_genre": "25"}}))
+	fmt.Println(AppPrimaryGenre(map[string]any{"common": map[string]any{"primary_genre": "999"}}))
+	_, err := AppPrimaryGenre(map[string]any{"common": map[string]any{}})
+	fmt.Println(errors.Is(err, ErrFieldNotFound))
+	// Output:
+	// {25 Adventure} <nil>
+	// {999 } <nil>
+	// true
+}
+
+func ExampleAppAchievementCount() {
+	output := map[string]any{"stats": map[string]any{
+		"1": map[string]any{"type": "4", "name": "ACH_FIRST_LEVEL"},
+		"2": map[string]any{"type": "4", "name": "ACH_ALL_LEVELS"},
+		"3": map[string]any{"type": "1", "name": "STAT_DEATHS"},
+	}}
+	fmt.Println(AppAchievementCount(output))
+	fmt.Println(AppAchievementCount(map[string]any{"common": map[string]any{"achievement_count": "55"}}))
+	fmt.Println(AppHasAchievements(output), AppHasAchievements(map[string]any{"common": map[string]any{}}))
+	// Output:
+	// 2 <nil>
+	// 55 <nil>
+	// true false
+}
+
+func ExampleAppSteamBadges() {
+	output := map[string]any{"steam_badges": map[string]any{
+		"2": map[string]any{"name": "Clumsy", "image": "https://example.com/badge_2.png"},
+		"1": map[string]any{"level": "1", "name": "Wobbly", "image": "https://example.com/badge_1.png"},
+		"5": map[string]any{"level": "5", "name": "Human"},
+	}}
+	badges, err := AppSteamBadges(output)
+	for _, badge := range badges {
+		fmt.Printf("%+v\n", badge)
+	}
+	fmt.Println(err)
+	fmt.Println(AppMaxBadgeLevel(output))
+	fmt.Println(AppHasBadges(output), AppHasBadges(map[string]any{"steam_badges": map[string]any{}}))
+	// Output:
+	// {Level:1 Name:Wobbly ImageURL:https://example.com/badge_1.png}
+	// {Level:2 Name:Clumsy ImageURL:https://example.com/badge_2.png}
+	// {Level:5 Name:Human ImageURL:}
+	// <nil>
+	// 5 <nil>
+	// true false
+}
+
+func ExampleAppEULAs() {
+	output := map[string]any{"extended": map[string]any{
+		"eulas": map[string]any{
+			"1": map[string]any{"id": "477160_eula_1", "name": "Online EULA", "url": "https://example.com/online"},
+			"0": map[string]any{"id": "477160_eula_0", "name": "EULA", "url": "https://example.com/eula"},
+		},
+	}}
+	fmt.Println(AppEULAs(output))
+	fmt.Println(AppRequiresEULA(output))
+	fmt.Println(AppRequiresEULA(map[string]any{"extended": map[string]any{}}))
+	// Output:
+	// [{477160_eula_0 EULA https://example.com/eula} {477160_eula_1 Online EULA https://example.com/online}] <nil>
+	// true
+	// false
+}
+
+func ExampleAppStoreTags() {
+	output := map[string]any{"common": map[string]any{
+		"store_tags": map[string]any{"0": "1664", "1": "4136", "10": "3859", "2": "1685"},
+	}}
+	fmt.Println(AppStoreTags(output))
+	fmt.Println(AppHasTag(output, "3859"), AppHasTag(output, "122"))
+	// Output:
+	// [1664 4136 1685 3859]
+	// true false
+}
+
+func TestAppUserDefinedTags(t *testing.T) {
+	for testNo, test := range []struct {
+		output                  map[string]any
+		expectedStoreTags       []string
+		expectedUserDefinedTags []string
+	}{
+		{
+			map[string]any{"common": map[string]any{
+				"store_tags":        map[string]any{"0": "1664", "1": "4136"},
+				"user_defined_tags": map[string]any{"1": "3859", "0": "492"},
+			}},
+			[]string{"1664", "4136"},
+			[]string{"492", "3859"},
+		},
+		{
+			map[string]any{"common": map[string]any{"store_tags": map[string]any{"0": "1664"}}},
+			[]string{"1664"},
+			nil,
+		},
+		{
+			map[string]any{"common": map[string]any{"user_defined_tags": map[string]any{"0": "492"}}},
+			nil,
+			[]string{"492"},
+		},
+		{
+			map[string]any{"common": map[string]any{}},
+			nil,
+			nil,
+		},
+	} {
+		if storeTags := AppStoreTags(test.output); !reflect.DeepEqual(storeTags, test.expectedStoreTags) {
+			t.Errorf("%d: expected store tags %v, got %v", testNo+1, test.expectedStoreTags, storeTags)
+		}
+
+		if tags := AppTags(test.output); !reflect.DeepEqual(tags, test.expectedStoreTags) {
+			t.Errorf("%d: expected AppTags to return %v, got %v", testNo+1, test.expectedStoreTags, tags)
+		}
+
+		userDefinedTags := AppUserDefinedTags(test.output)
+		if !reflect.DeepEqual(userDefinedTags, test.expectedUserDefinedTags) {
+			t.Errorf("%d: expected user defined tags %v, got %v", testNo+1, test.expectedUserDefinedTags, userDefinedTags)
+		}
+	}
+}
+
+func ExampleAppLanguages() {
+	output := map[string]any{"common": map[string]any{
+		"languages": map[string]any{"french": "1", "english": "1", "german": "0"},
+	}}
+	fmt.Println(AppLanguages(output))
+	fmt.Println(AppSupportsLanguage(output, "English"))
+	fmt.Println(AppSupportsLanguage(output, "german"))
+	fmt.Println(AppLanguages(map[string]any{"common": map[string]any{"languages": "spanish, english,french"}}))
+	// Output:
+	// [english french] <nil>
+	// true
+	// false
+	// [english french spanish] <nil>
+}
+
+func TestAppRequiredAge(t *testing.T) {
+	for testNo, test := range []struct {
+		output        map[string]any
+		expectedAge   int
+		expectedAdult bool
+		expectedErr   error
+	}{
+		{map[string]any{"common": map[string]any{"required_age": "0"}}, 0, false, nil},
+		{map[string]any{"common": map[string]any{"required_age": "18"}}, 18, true, nil},
+		{map[string]any{"common": map[string]any{"required_age": 21.0}}, 21, true, nil},
+		{map[string]any{"common": map[string]any{"required_age": "16"}}, 16, false, nil},
+		{map[string]any{"common": map[string]any{}}, 0, false, ErrFieldNotFound},
+	} {
+		age, err := AppRequiredAge(test.output)
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%d: expected error %v, got %v", testNo+1, test.expectedErr, err)
+		}
+		if age != test.expectedAge {
+			t.Errorf("%d: expected age %d, got %d", testNo+1, test.expectedAge, age)
+		}
+		if adult := AppIsAdultContent(test.output); adult != test.expectedAdult {
+			t.Errorf("%d: expected AppIsAdultContent to be %t, got %t", testNo+1, test.expectedAdult, adult)
+		}
+	}
+}
+
+func ExampleAppContentDescriptors() {
+	output := map[string]any{"common": map[string]any{
+		"content_descriptors": map[string]any{"0": "5", "1": "2"},
+	}}
+	fmt.Println(AppContentDescriptors(output))
+	fmt.Println(AppHasContentDescriptor(output, ContentDescriptorViolence))
+	fmt.Println(AppHasContentDescriptor(output, ContentDescriptorNudity))
+	fmt.Println(AppContentDescriptors(map[string]any{"common": map[string]any{
+		"content_descriptors": map[string]any{"ids": "1, 3"},
+	}}))
+	fmt.Println(AppContentDescriptors(map[string]any{"common": map[string]any{
+		"content_descriptors": map[string]any{"0": map[string]any{"id": "2", "description": "Blood"}},
+	}}))
+	// Output:
+	// [{2 Frequent Violence or Gore} {5 General Mature Content}] <nil>
+	// true
+	// false
+	// [{1 Some Nudity or Sexual Content} {3 Adult Only Sexual Content}] <nil>
+	// [{2 Blood}] <nil>
+}
+
+func ExampleAppAdultContentReason() {
+	output := map[string]any{"common": map[string]any{
+		"required_age":        "18",
+		"content_descriptors": map[string]any{"0": "1", "1": "3"},
+		"genres":              map[string]any{"0": "23", "1": "71"},
+	}}
+	for _, reason := range AppAdultContentReason(output) {
+		fmt.Println(reason)
+	}
+	fmt.Println(AppIsAdultOnly(output))
+	fmt.Println(AppIsAdultOnly(map[string]any{"common": map[string]any{"required_age": "16"}}))
+	// Output:
+	// required age is 18
+	// has the "Adult Only Sexual Content" content descriptor
+	// has the "Sexual Content" genre
+	// true
+	// false
+}
+
+func TestAppAdultContentReason_categories(t *testing.T) {
+	defer func(categories []int) { AdultOnlyCategories = categories }(AdultOnlyCategories)
+	output := map[string]any{"common": map[string]any{
+		"category": map[string]any{"category_2": "1", "category_998": "1", "category_999": "1"},
+	}}
+
+	for testNo, test := range []struct {
+		categories []int
+		expected   []string
+	}{
+		{nil, []string{}},
+		{[]int{998}, []string{"has the category with ID 998"}},
+		{[]int{999, 998}, []string{"has the category with ID 998", "has the category with ID 999"}},
+		{[]int{CategorySinglePlayer}, []string{`has the "Single-player" category`}},
+		{[]int{CategoryMultiPlayer}, []string{}},
+	} {
+		AdultOnlyCategories = test.categories
+		if reasons := AppAdultContentReason(output); !reflect.DeepEqual(reasons, test.expected) {
+			t.Errorf("%d: expected reasons %q, got %q", testNo+1, test.expected, reasons)
+		}
+
+		if adult := AppIsAdultOnly(output); adult != (len(test.expected) > 0) {
+			t.Errorf("%d: expected AppIsAdultOnly to be %t, got %t", testNo+1, len(test.expected) > 0, adult)
+		}
+	}
+}
+
+func ExampleAppImageURL() {
+	output := map[string]any{"common": map[string]any{
+		"header_image":  "https://cdn.akamai.steamstatic.com/steam/apps/477160/header.jpg",
+		"capsule_image": map[string]any{"english": "capsule.jpg"},
+	}}
+	fmt.Println(AppHeaderImageURL(output))
+	fmt.Println(AppCapsuleImageURL(output))
+	_, err := AppBackgroundImageURL(output)
+	fmt.Println(errors.Is(err, ErrFieldNotFound))
+	// Output:
+	// https://cdn.akamai.steamstatic.com/steam/apps/477160/header.jpg <nil>
+	//  capsule_image is not a string, it is a map[string]interface {}
+	// true
+}
+
+func TestAppIconURL(t *testing.T) {
+	urlPattern := regexp.MustCompile(`^https://media\.steampowered\.com/steamcommunity/public/images/apps/\d+/[0-9a-f]{40}\.jpg$`)
+	output := map[string]any{"common": map[string]any{
+		"icon": "48f7f1df4ed9b8a4d4f9d3e0b0c0c0a5b5f6b7e4",
+		"logo": "6c7f1c8b6a3e6f1b5c0d0b1c6f0f0c3e0c9b0d1a",
+	}}
+
+	for testNo, test := range []struct {
+		urlFunc     func(output map[string]any, appID int64) (string, error)
+		expectedURL string
+	}{
+		{AppIconURL, SteamMediaBaseURL + "/477160/48f7f1df4ed9b8a4d4f9d3e0b0c0c0a5b5f6b7e4.jpg"},
+		{AppLogoURL, SteamMediaBaseURL + "/477160/6c7f1c8b6a3e6f1b5c0d0b1c6f0f0c3e0c9b0d1a.jpg"},
+	} {
+		url, err := test.urlFunc(output, 477160)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", testNo+1, err)
+			continue
+		}
+
+		if url != test.expectedURL || !urlPattern.MatchString(url) {
+			t.Errorf("%d: expected URL %q, got %q", testNo+1, test.expectedURL, url)
+		}
+	}
+
+	for _, emptyOutput := range []map[string]any{{"common": map[string]any{}}, {"common": map[string]any{"icon": ""}}} {
+		if _, err := AppIconURL(emptyOutput, 477160); !errors.Is(err, ErrFieldNotFound) {
+			t.Errorf("expected ErrFieldNotFound for %v, got %v", emptyOutput, err)
+		}
+	}
+}
+
+func TestAppDefaultInstallPath(t *testing.T) {
+	output := map[string]any{"config": map[string]any{"installdir": "Human Fall Flat"}}
+	for testNo, test := range []struct {
+		separator    string
+		steamLibrary string
+		expectedPath string
+	}{
+		{"/", "/home/user/.steam/steam", "/home/user/.steam/steam/steamapps/common/Human Fall Flat"},
+		{"/", "/home/user/.steam/steam/", "/home/user/.steam/steam/steamapps/common/Human Fall Flat"},
+		{"/", "/", "/steamapps/common/Human Fall Flat"},
+		{"/", "", "steamapps/common/Human Fall Flat"},
+		{`\`, `C:\Program Files (x86)\Steam`, `C:\Program Files (x86)\Steam\steamapps\common\Human Fall Flat`},
+		{`\`, `D:\SteamLibrary\`, `D:\SteamLibrary\steamapps\common\Human Fall Flat`},
+	} {
+		installDir, err := AppInstallDirName(output)
+		if err != nil {
+			t.Fatalf("%d: could not get install dir name: %v", testNo+1, err)
+		}
+
+		if path := joinInstallPath(test.separator, test.steamLibrary, installDir); path != test.expectedPath {
+			t.Errorf("%d: expected path %q, got %q", testNo+1, test.expectedPath, path)
+		}
+	}
+
+	if path := AppDefaultInstallPath(output, "steam"); path != filepath.Join("steam", "steamapps", "common", "Human Fall Flat") {
+		t.Errorf("expected AppDefaultInstallPath to use the OS path separator, got %q", path)
+	}
+
+	if path := AppDefaultInstallPath(map[string]any{}, "steam"); path != "" {
+		t.Errorf("expected an empty path when installdir cannot be found, got %q", path)
+	}
+}
+
+func ExampleAppBuildID() {
+	output := map[string]any{"depots": map[string]any{"branches": map[string]any{
+		"public": map[string]any{"buildid": "9876543", "timeupdated": "1665000000"},
+		"beta":   map[string]any{"buildid": "9876999", "timeupdated": "1665100000"},
+	}}}
+	fmt.Println(AppBuildID(output))
+	fmt.Println(AppBuildIDForBranch(output, "beta"))
+	fmt.Println(AppLastUpdated(output))
+	_, err := AppBuildIDForBranch(output, "alpha")
+	fmt.Println(errors.Is(err, ErrFieldNotFound))
+	// Output:
+	// 9876543 <nil>
+	// 9876999 <nil>
+	// 2022-10-05 20:00:00 +0000 UTC <nil>
+	// true
+}
+
+func TestChangeNumbers(t *testing.T) {
+	// The app_info_change_number within this fixture differs from the change number in its header
+	b, err := os.ReadFile(filepath.Join("testdata", "app_info_print_change_number.txt"))
+	if err != nil {
+		t.Fatalf("could not read fixture: %v", err)
+	}
+
+	var headerChangeNumber int64
+	if headerChangeNumber, err = HeaderChangeNumber(b); err != nil || headerChangeNumber != 16411497 {
+		t.Errorf("expected a header change number of 16411497, got %d (err: %v)", headerChangeNumber, err)
+	}
+
+	var parsedOutput any
+	if parsedOutput, err = parseAppInfoPrint(b); err != nil {
+		t.Fatalf("could not parse fixture: %v", err)
+	}
+	output := parsedOutput.(map[string]any)
+
+	var mapChangeNumber int64
+	if mapChangeNumber, err = MapChangeNumber(output); err != nil || mapChangeNumber != 16411480 {
+		t.Errorf("expected a map change number of 16411480, got %d (err: %v)", mapChangeNumber, err)
+	}
+
+	if changeNumber, _ := ChangeNumber(output); changeNumber != mapChangeNumber {
+		t.Errorf("expected ChangeNumber to be %d, got %d", mapChangeNumber, changeNumber)
+	}
+
+	if _, err = HeaderChangeNumber([]byte(`"477160"`)); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("expected ErrFieldNotFound for output without a header, got %v", err)
+	}
+
+	if _, err = MapChangeNumber(map[string]any{}); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("expected ErrFieldNotFound for output without app_info_change_number, got %v", err)
+	}
+}
+
+func TestAppPackageIDs(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("testdata", "app_info_print_620.txt"))
+	if err != nil {
+		t.Fatalf("could not read fixture: %v", err)
+	}
+
+	var parsedOutput any
+	if parsedOutput, err = parseAppInfoPrint(b); err != nil {
+		t.Fatalf("could not parse fixture: %v", err)
+	}
+
+	var packageIDs []int64
+	if packageIDs, err = AppPackageIDs(parsedOutput.(map[string]any)); err != nil {
+		t.Fatalf("could not get package IDs: %v", err)
+	}
+
+	if expected := []int64{204, 7877, 7878, 14926, 32848}; !reflect.DeepEqual(packageIDs, expected) {
+		t.Errorf("expected package IDs %v, got %v", expected, packageIDs)
+	}
+
+	if _, err = AppPackageIDs(map[string]any{"common": map[string]any{}}); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("expected ErrFieldNotFound, got %v", err)
+	}
+}
+
+func ExampleAppPublicManifest() {
+	output := map[string]any{"depots": map[string]any{
+		"477161": map[string]any{"manifests": map[string]any{
+			"public": map[string]any{"gid": "4866428917045423071", "size": "1540366000", "download": "816420656"},
+		}},
+		"477162": map[string]any{"manifests": map[string]any{"public": "7206891729271020712"}},
+		"477163": map[string]any{"config": map[string]any{"oslist": "linux"}},
+	}}
+	manifest, err := AppPublicManifest(output, "477161")
+	fmt.Printf("%+v %v\n", *manifest, err)
+	manifest, err = AppPublicManifest(output, "477162")
+	fmt.Printf("%+v %v\n", *manifest, err)
+	_, err = AppPublicManifest(output, "477163")
+	fmt.Println(errors.Is(err, ErrFieldNotFound))
+	// Output:
+	// {ManifestID:4866428917045423071 Size:1540366000 Download:816420656 GID:4866428917045423071} <nil>
+	// {ManifestID:7206891729271020712 Size:0 Download:0 GID:} <nil>
+	// true
+}
+
+func TestAppDepotForPlatform(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("testdata", "app_info_print_477160.txt"))
+	if err != nil {
+		t.Fatalf("could not read fixture: %v", err)
+	}
+
+	var parsedOutput any
+	if parsedOutput, err = parseAppInfoPrint(b); err != nil {
+		t.Fatalf("could not parse fixture: %v", err)
+	}
+	fixture := parsedOutput.(map[string]any)
+
+	shared := map[string]any{"depots": map[string]any{
+		"100": map[string]any{"name": "Content"},
+		"101": map[string]any{"config": map[string]any{"oslist": "windows,linux"}},
+		"102": map[string]any{"config": map[string]any{"oslist": "linux", "language": "german"}},
+		"103": map[string]any{"config": map[string]any{"oslist": "linux"}, "dlcappid": "200"},
+		"104": map[string]any{"config": map[string]any{"osbitmask": "4"}},
+		"105": map[string]any{"binary": "1"},
+	}}
+
+	for testNo, test := range []struct {
+		output          map[string]any
+		platform        string
+		expectedDepotID string
+		expectedErr     error
+	}{
+		{fixture, "windows", "477161", nil},
+		{fixture, "MacOS", "477162", nil},
+		{fixture, "linux", "477163", nil},
+		{shared, "linux", "101", nil},
+		{shared, "macos", "", ErrFieldNotFound},
+		{map[string]any{"common": map[string]any{}}, "linux", "", ErrFieldNotFound},
+	} {
+		depot, err := AppDepotForPlatform(test.output, test.platform)
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%d: expected error %v, got %v", testNo+1, test.expectedErr, err)
+			continue
+		}
+
+		if test.expectedErr == nil && depot.ID != test.expectedDepotID {
+			t.Errorf("%d: expected depot %s, got %s", testNo+1, test.expectedDepotID, depot.ID)
+		}
+	}
+
+	depots, err := AppBinaryDepots(shared)
+	if err != nil {
+		t.Fatalf("could not get binary depots: %v", err)
+	}
+	if _, ok := depots["100"]; ok || len(depots) != 5 {
+		t.Errorf("expected binary depots 101 to 105, got %v", depots)
+	}
+}
+
+func TestAppHasAssetChangedSince(t *testing.T) {
+	for testNo, test := range []struct {
+		output   map[string]any
+		since    time.Time
+		expected bool
+	}{
+		{
+			map[string]any{"common": map[string]any{"store_asset_mtime": "1665000000"}},
+			time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+			true,
+		},
+		{
+			map[string]any{"common": map[string]any{"store_asset_mtime": "1665000000"}},
+			time.Date(2022, 10, 6, 0, 0, 0, 0, time.UTC),
+			false,
+		},
+		{
+			map[string]any{"common": map[string]any{"store_asset_mtime": int64(1665000000)}},
+			time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+			true,
+		},
+		{
+			map[string]any{"common": map[string]any{"name": "Human: Fall Flat"}},
+			time.Time{},
+			false,
+		},
+	} {
+		if changed := AppHasAssetChangedSince(test.output, test.since); changed != test.expected {
+			t.Errorf("%d: expected %t, got %t", testNo+1, test.expected, changed)
+		}
+	}
+
+	mtime, err := AppStoreAssetMTime(map[string]any{"common": map[string]any{"store_asset_mtime": "1665000000"}})
+	if expected := time.Unix(1665000000, 0).UTC(); err != nil || !mtime.Equal(expected) {
+		t.Errorf("expected %v, got %v (err: %v)", expected, mtime, err)
+	}
+
+	if _, err = AppStoreAssetMTime(map[string]any{}); !errors.Is(err, ErrFieldNotFound) {
+		t.Errorf("expected ErrFieldNotFound, got %v", err)
+	}
+}
+
+func TestAppReviewCounts(t *testing.T) {
+	for testNo, test := range []struct {
+		output           map[string]any
+		expectedPositive int64
+		expectedNegative int64
+		expectedPct      float64
+		expectedErr      error
+	}{
+		{
+			map[string]any{"common": map[string]any{
+				"review_percentage": "96",
+				"total_positive":    "1920",
+				"total_negative":    "80",
+			}},
+			1920, 80, 96, nil,
+		},
+		{
+			map[string]any{"common": map[string]any{"total_positive": "75", "total_negative": "25"}},
+			75, 25, 75, nil,
+		},
+		{
+			map[string]any{"common": map[string]any{"review_score": "8", "review_percentage": "91"}},
+			0, 0, 91, ErrFieldNotFound,
+		},
+		{
+			map[string]any{"common": map[string]any{"review_score": "8"}},
+			0, 0, 0, ErrFieldNotFound,
+		},
+	} {
+		positive, negative, pct, err := AppReviewCounts(test.output)
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%d: expected error %v, got %v", testNo+1, test.expectedErr, err)
+		}
+
+		if positive != test.expectedPositive || negative != test.expectedNegative || pct != test.expectedPct {
+			t.Errorf(
+				"%d: expected (%d, %d, %f), got (%d, %d, %f)", testNo+1,
+				test.expectedPositive, test.expectedNegative, test.expectedPct, positive, negative, pct,
+			)
+		}
+	}
+}
+
+func TestAppReviewRatio(t *testing.T) {
+	for testNo, test := range []struct {
+		output        map[string]any
+		expectedRatio float64
+		expectedErr   error
+	}{
+		{map[string]any{"common": map[string]any{"total_positive": "75", "total_negative": "25"}}, 0.75, nil},
+		{map[string]any{"common": map[string]any{"total_positive": "10", "total_negative": "0"}}, 1, nil},
+		{map[string]any{"common": map[string]any{"total_positive": "0", "total_negative": "0"}}, 0, ErrFieldNotFound},
+		{map[string]any{"common": map[string]any{"total_positive": "10"}}, 0, ErrFieldNotFound},
+		{map[string]any{"common": map[string]any{"total_negative": "10"}}, 0, ErrFieldNotFound},
+	} {
+		ratio, err := AppReviewRatio(test.output)
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%d: expected error %v, got %v", testNo+1, test.expectedErr, err)
+		}
+
+		if ratio != test.expectedRatio {
+			t.Errorf("%d: expected ratio %f, got %f", testNo+1, test.expectedRatio, ratio)
+		}
+	}
+}
+
+func TestAppIsFreeWeekendNow(t *testing.T) {
+	output := map[string]any{"common": map[string]any{
+		"free_weekend": map[string]any{"start": "1665000000", "end": "1665259200"},
+	}}
+
+	freeWeekend, err := AppFreeWeekend(output)
+	if err != nil {
+		t.Fatalf("could not get free weekend: %v", err)
+	}
+
+	expected := FreeWeekend{Start: time.Unix(1665000000, 0).UTC(), End: time.Unix(1665259200, 0).UTC()}
+	if *freeWeekend != expected {
+		t.Errorf("expected free weekend %v, got %v", expected, *freeWeekend)
+	}
+
+	for testNo, test := range []struct {
+		now      time.Time
+		expected bool
+	}{
+		{time.Unix(1664999999, 0).UTC(), false},
+		{time.Unix(1665000000, 0).UTC(), true},
+		{time.Unix(1665100000, 0).UTC(), true},
+		{time.Unix(1665259200, 0).UTC(), false},
+	} {
+		if isFreeWeekend, err := appIsFreeWeekendAt(output, test.now); err != nil || isFreeWeekend != test.expected {
+			t.Errorf("%d: expected %t, got %t (err: %v)", testNo+1, test.expected, isFreeWeekend, err)
+		}
+	}
+
+	if isFreeWeekend, err := AppIsFreeWeekendNow(output); err != nil || isFreeWeekend {
+		t.Errorf("expected the free weekend to have ended, got %t (err: %v)", isFreeWeekend, err)
+	}
+
+	_, err = AppIsFreeWeekendNow(map[string]any{"appid": "620", "common": map[string]any{}})
+	var noFreeWeekendErr ErrNoFreeWeekend
+	if !errors.As(err, &noFreeWeekendErr) || noFreeWeekendErr.AppID != 620 {
+		t.Errorf("expected ErrNoFreeWeekend for 620, got %v", err)
+	}
+}
+
+func TestAppIsEarlyAccess(t *testing.T) {
+	for testNo, test := range []struct {
+		output   map[string]any
+		expected bool
+	}{
+		{map[string]any{"common": map[string]any{"category": map[string]any{"category_70": "1"}}}, true},
+		{map[string]any{"common": map[string]any{"early_access_gameid": "1234560"}}, true},
+		{map[string]any{"extended": map[string]any{"early_access_gameid": "1234560"}}, true},
+		{
+			map[string]any{"common": map[string]any{
+				"category":            map[string]any{"category_2": "1", "category_70": "1"},
+				"early_access_gameid": "1234560",
+			}},
+			true,
+		},
+		{map[string]any{"common": map[string]any{"early_access_gameid": "0"}}, false},
+		{map[string]any{"common": map[string]any{"category": map[string]any{"category_2": "1"}}}, false},
+	} {
+		if earlyAccess := AppIsEarlyAccess(test.output); earlyAccess != test.expected {
+			t.Errorf("%d: expected %t, got %t", testNo+1, test.expected, earlyAccess)
+		}
+	}
+}
+
+func TestAppSupportedLanguagesDetail(t *testing.T) {
+	for testNo, test := range []struct {
+		output            map[string]any
+		expectedLanguages map[string]LanguageSupport
+		expectedErr       error
+	}{
+		{
+			map[string]any{"common": map[string]any{"supported_languages": map[string]any{
+				"english": map[string]any{"supported": "true", "full_audio": "true", "subtitles": "true"},
+				"french":  map[string]any{"supported": "true", "subtitles": "true"},
+				"german":  map[string]any{"supported": "true"},
+				"russian": map[string]any{"subtitles": "true"},
+			}}},
+			map[string]LanguageSupport{
+				"english": {Interface: true, FullAudio: true, Subtitles: true},
+				"french":  {Interface: true, Subtitles: true},
+				"german":  {Interface: true},
+				"russian": {Subtitles: true},
+			},
+			nil,
+		},
+		{
+			map[string]any{"common": map[string]any{
+				"supported_languages": "English<strong>*</strong>, French, Simplified Chinese<strong>*</strong><br><strong>*</strong>languages with full audio support",
+			}},
+			map[string]LanguageSupport{
+				"english":            {Interface: true, FullAudio: true, Subtitles: true},
+				"french":             {Interface: true, Subtitles: true},
+				"simplified chinese": {Interface: true, FullAudio: true, Subtitles: true},
+			},
+			nil,
+		},
+		{
+			map[string]any{"common": map[string]any{"supported_languages": "English*|Portuguese - Brazil"}},
+			map[string]LanguageSupport{
+				"english":             {Interface: true, FullAudio: true, Subtitles: true},
+				"portuguese - brazil": {Interface: true, Subtitles: true},
+			},
+			nil,
+		},
+		{map[string]any{"common": map[string]any{}}, nil, ErrFieldNotFound},
+	} {
+		languages, err := AppSupportedLanguagesDetail(test.output)
+		if !errors.Is(err, test.expectedErr) {
+			t.Errorf("%d: expected error %v, got %v", testNo+1, test.expectedErr, err)
+		}
+
+		if !reflect.DeepEqual(languages, test.expectedLanguages) {
+			t.Errorf("%d: expected languages %v, got %v", testNo+1, test.expectedLanguages, languages)
+		}
+	}
+}
+
+func ExampleAppShortDescription() {
+	output := map[string]any{"common": map[string]any{
+		"short_description":    "Human: Fall Flat is a quirky, open-ended physics-based puzzle &amp; exploration game.",
+		"detailed_description": "&quot;Bob&quot; is just a normal human.",
+	}}
+	fmt.Println(AppShortDescription(output))
+	fmt.Println(AppFullDescription(output))
+	_, err := AppFullDescription(map[string]any{"common": map[string]any{}})
+	fmt.Println(errors.Is(err, ErrFieldNotFound))
+	// Output:
+	// Human: Fall Flat is a quirky, open-ended physics-based puzzle & exploration game. <nil>
+	// "Bob" is just a normal human. <nil>
+	// true
+}
+
+func ExampleAppCategories() {
+	output := map[string]any{"common": map[string]any{
+		"category": map[string]any{"category_2": "1", "category_22": "1", "category_9": "1", "category_1": "0"},
+	}}
+	fmt.Println(AppCategories(output))
+	fmt.Println(AppIsSingleplayer(output), AppIsMultiplayer(output))
+	output = map[string]any{"common": map[string]any{
+		"category": map[string]any{"0": map[string]any{"id": "2", "description": "Single-player"}},
+	}}
+	fmt.Println(AppCategories(output))
+	fmt.Println(AppIsSingleplayer(output), AppIsMultiplayer(output))
+	// Output:
+	// [{2 Single-player} {9 Co-op} {22 Steam Achievements}] <nil>
+	// true true
+	// [{2 Single-player}] <nil>
+	// true false
+}
+
+func ExampleAppOpenVRControllerBindings() {
+	output := map[string]any{"openvr": map[string]any{"controller_bindings": map[string]any{
+		"vive":     "https://example.com/bindings/vive.json",
+		"knuckles": "https://example.com/bindings/knuckles.json",
+	}}}
+	bindings, err := AppOpenVRControllerBindings(output)
+	fmt.Println(bindings["knuckles"], bindings["vive"], err)
+	fmt.Println(AppSupportsVR(output), AppSupportsVR(map[string]any{"common": map[string]any{}}))
+	// Output:
+	// https://example.com/bindings/knuckles.json https://example.com/bindings/vive.json <nil>
+	// true false
+}
+
+func ExampleAppLocalizationPaths() {
+	output := map[string]any{"localization": map[string]any{
+		"french": "localization/fr",
+		"german": "localization/de",
+	}}
+	paths, err := AppLocalizationPaths(output)
+	fmt.Println(paths, err)
+	fmt.Println(AppHasLocalization(output, "german"), AppHasLocalization(output, "spanish"))
+	_, err = AppLocalizationPaths(map[string]any{"common": map[string]any{}})
+	fmt.Println(errors.Is(err, ErrFieldNotFound))
+	// Output:
+	// map[french:localization/fr german:localization/de] <nil>
+	// true false
+	// true
+}
+
+func ExampleAppAllClientBetas() {
+	output := map[string]any{"extended": map[string]any{
+		"linuxclientbeta": "linux_beta",
+		"macosclientbeta": "macos_beta",
+		"isfreeapp":       "0",
+	}}
+	fmt.Println(AppClientBetaForPlatform(output, "linux"))
+	fmt.Println(AppAllClientBetas(output))
+	_, err := AppClientBetaForPlatform(output, "windows")
+	fmt.Println(errors.Is(err, ErrFieldNotFound))
+	// Output:
+	// linux_beta <nil>
+	// map[linuxclientbeta:linux_beta macosclientbeta:macos_beta]
+	// true
+}
+
+func ExampleAppCommunityHub() {
+	output := map[string]any{
+		"appid":         "477160",
+		"common":        map[string]any{"category": map[string]any{"category_30": "1"}},
+		"community_hub": map[string]any{},
+	}
+	hub, err := AppCommunityHub(output)
+	fmt.Printf("%+v %v\n", *hub, err)
+	delete(output, "community_hub")
+	_, err = AppCommunityHub(output)
+	fmt.Println(AppHasCommunityHub(output), errors.Is(err, ErrFieldNotFound))
+	// Output:
+	// {DiscussionURL:https://steamcommunity.com/app/477160/discussions/ WorkshopURL:https://steamcommunity.com/app/477160/workshop/ TagStore:https://store.steampowered.com/app/477160/} <nil>
+	// false true
+}
+
+func ExampleAppSupportedInput() {
+	flags, err := AppSupportedInput(map[string]any{"common": map[string]any{"supported_input": "5"}})
+	fmt.Println(flags, err)
+	fmt.Println(flags.Has(InputFlagKeyboardMouse), flags.Has(InputFlagGamepad), flags.Has(InputFlagSteamController))
+	fmt.Println(InputFlags(0), InputFlagGamepad|InputFlags(16))
+	_, err = AppSupportedInput(map[string]any{"common": map[string]any{}})
+	fmt.Println(errors.Is(err, ErrFieldNotFound))
+	// Output:
+	// Keyboard/Mouse|Steam Controller <nil>
+	// true false true
+	// None Gamepad|0x10
+	// true
+}
+
+func ExampleAppVRPlatforms() {
+	output := map[string]any{"common": map[string]any{
+		"vronly":   "1",
+		"category": map[string]any{"category_101": "1", "category_104": "1", "category_401": "1"},
+	}}
+	fmt.Println(AppIsVROnly(output), AppIsVRSupported(output), AppVRPlatforms(output))
+	output = map[string]any{"common": map[string]any{"category": map[string]any{"category_2": "1"}}}
+	fmt.Println(AppIsVROnly(output), AppIsVRSupported(output), AppVRPlatforms(output))
+	// Output:
+	// true true [OpenVR WindowsMR]
+	// false false []
+}
+
+func ExampleAppHasWorkshop() {
+	output := map[string]any{
+		"common":   map[string]any{"category": map[string]any{"category_2": "1", "category_30": "1"}},
+		"extended": map[string]any{"workshop_visible": "1"},
+	}
+	fmt.Println(AppHasWorkshop(output), AppWorkshopVisible(output))
+	output = map[string]any{"common": map[string]any{"category": map[string]any{"category_2": "1"}}}
+	fmt.Println(AppHasWorkshop(output), AppWorkshopVisible(output))
+	// Output:
+	// true true
+	// false false
+}
+
+func ExampleAppSteamDeckCompat() {
+	output := map[string]any{"common": map[string]any{
+		"steam_deck_compatibility": map[string]any{"category": "3", "test_timestamp": "1665000000"},
+	}}
+	fmt.Println(AppSteamDeckCompat(output))
+	fmt.Println(AppIsSteamDeckVerified(output))
+	output = map[string]any{"common": map[string]any{"steam_deck_compat": map[string]any{"category": "2"}}}
+	fmt.Println(AppSteamDeckCompat(output))
+	fmt.Println(AppIsSteamDeckVerified(output))
+	// Output:
+	// Verified <nil>
+	// true
+	// Playable <nil>
+	// false
+}
+
+func ExampleAppHasNativeControllerSupport() {
+	for _, controllerType := range []string{"0", "1", "2", "3"} {
+		output := map[string]any{"common": map[string]any{"controllertype": controllerType}}
+		value, _ := AppControllerType(output)
+		fmt.Println(value, AppHasNativeControllerSupport(output))
+	}
+	fmt.Println(AppHasNativeControllerSupport(map[string]any{"common": map[string]any{}}))
+	// Output:
+	// 0 false
+	// 1 false
+	// 2 true
+	// 3 true
+	// false
+}
+
+func ExampleAppAssociations() {
+	output := map[string]any{"common": map[string]any{
+		"associations": map[string]any{
+			"0": map[string]any{"type": "developer", "name": "No Brakes Games"},
+			"1": map[string]any{"type": "publisher", "name": "Curve Games"},
+			"2": map[string]any{"type": "publisher", "name": "505 Games"},
+		},
+		"developer": "Legacy Developer",
+	}}
+	fmt.Println(AppAssociations(output))
+	fmt.Println(AppDevelopers(output), AppPublishers(output))
+	output = map[string]any{"common": map[string]any{
+		"developer": "No Brakes Games",
+		"publisher": map[string]any{"0": "Curve Games", "1": "505 Games"},
+	}}
+	fmt.Println(AppDevelopers(output), AppPublishers(output))
+	// Output:
+	// [{No Brakes Games developer} {Curve Games publisher} {505 Games publisher}] <nil>
+	// [No Brakes Games] [Curve Games 505 Games]
+	// [No Brakes Games] [Curve Games 505 Games]
+}
+
+func ExampleAppWebsite() {
+	output := map[string]any{"common": map[string]any{
+		"homepage":     "",
+		"website":      "http://www.humanfallflat.com",
+		"support_info": map[string]any{"url": "https://support.curve.co.uk", "email": "support@curve.co.uk"},
+	}}
+	fmt.Println(AppWebsite(output))
+	fmt.Println(AppSupportURL(output))
+	_, err := AppWebsite(map[string]any{"common": map[string]any{}})
+	fmt.Println(errors.Is(err, ErrFieldNotFound))
+	// Output:
+	// http://www.humanfallflat.com <nil>
+	// https://support.curve.co.uk <nil>
+	// true
+}
+
+func ExampleAppSteamControllerConfigURL() {
+	output := map[string]any{"common": map[string]any{
+		"steam_controller_support_url": "https://steamcommunity.com/app/477160/controller",
+	}}
+	fmt.Println(AppSteamControllerConfigURL(output))
+	_, err := AppSteamControllerConfigURL(map[string]any{"common": map[string]any{}})
+	fmt.Println(errors.Is(err, ErrFieldNotFound))
+	// Output:
+	// https://steamcommunity.com/app/477160/controller <nil>
+	// true
+}
+
+func TestAppExtendedBoolField(t *testing.T) {
+	output := map[string]any{"extended": map[string]any{"exfgls": "1", "isfreeapp": "0", "broken": "maybe"}}
+	for testNo, test := range []struct {
+		key           string
+		expectedValue bool
+		expectedErr   bool
+	}{
+		{"exfgls", true, false},
+		{"isfreeapp", false, false},
+		{"broken", false, true},
+		{"missing", false, true},
+	} {
+		value, err := AppExtendedBoolField(output, test.key)
+		if (err != nil) != test.expectedErr {
+			t.Errorf("%d: expected error to be %t, got %v", testNo+1, test.expectedErr, err)
+		}
+		if value != test.expectedValue {
+			t.Errorf("%d: expected %t, got %t", testNo+1, test.expectedValue, value)
+		}
+	}
+
+	if !AppExcludedFromFamilySharing(output) {
+		t.Errorf("expected app to be excluded from family sharing")
+	}
+	if AppExcludedFromFamilySharing(map[string]any{}) {
+		t.Errorf("expected app without an extended section to not be excluded from family sharing")
+	}
+}
+
+func ExampleAppVisibilityState() {
+	output := map[string]any{"common": map[string]any{"hidden": "1", "visibilitystate": "3"}}
+	fmt.Println(AppIsHidden(output), AppVisibilityState(output) == VisibilityUnlisted)
+	output = map[string]any{"common": map[string]any{"name": "Human: Fall Flat"}}
+	fmt.Println(AppIsHidden(output), AppVisibilityState(output) == VisibilityPublic)
+	// Output:
+	// true true
+	// false true
+}
+
+func TestCoerceNumericStrings(t *testing.T) {
+	input := map[string]any{
+		"appid": "477160",
+		"common": map[string]any{
+			"name":              "Human: Fall Flat",
+			"review_percentage": "95",
+			"ratio":             "1.5",
+			"infinity":          "Infinity",
+			"empty":             "",
+		},
+		"depots": map[string]any{
+			"477161": map[string]any{"manifests": map[string]any{"public": "18446744073709551615"}},
+		},
+	}
+	expected := map[string]any{
+		"appid": int64(477160),
+		"common": map[string]any{
+			"name":              "Human: Fall Flat",
+			"review_percentage": int64(95),
+			"ratio":             1.5,
+			"infinity":          "Infinity",
+			"empty":             "",
+		},
+		"depots": map[string]any{
+			"477161": map[string]any{"manifests": map[string]any{"public": "18446744073709551615"}},
+		},
+	}
+
+	if coerced := CoerceNumericStrings(input); !reflect.DeepEqual(coerced, expected) {
+		t.Errorf("expected %v, got %v", expected, coerced)
+	}
+
+	if input["appid"] != "477160" {
+		t.Errorf("expected the input to not be modified")
+	}
+}
+
+func ExampleSetNestedValue() {
+	output := make(map[string]any)
+	fmt.Println(SetNestedValue(output, "appid", "477160"))
+	fmt.Println(SetNestedValue(output, "common.name", "Human: Fall Flat"))
+	fmt.Println(SetNestedValue(output, "common.associations.0.name", "No Brakes Games"))
+	fmt.Println(output)
+	err := SetNestedValue(output, "common.name.english", "Human: Fall Flat")
+	fmt.Println(errors.Is(err, ErrPathConflict))
+	fmt.Println(SetNestedValue(nil, "appid", "477160"))
+	fmt.Println(SetNestedValue(map[string]any{"common": map[string]any(nil)}, "common.name", "Human: Fall Flat"))
+	// Output:
+	// <nil>
+	// <nil>
+	// <nil>
+	// map[appid:477160 common:map[associations:map[0:map[name:No Brakes Games]] name:Human: Fall Flat]]
+	// true
+	// cannot set "appid" within a nil map
+	// cannot set "common.name" as "common" is a nil map
+}
@@ -0,0 +1,26 @@
+package steamcmd
+
+import (
+	"github.com/pkg/errors"
+	"path/filepath"
+)
+
+// SetInstallDir will queue/execute the ForceInstallDir command for the given path using SteamCMD.AddCommandType. The
+// path is made absolute first, as steamcmd resolves relative install directories against its own directory rather than
+// the working directory. This must be called before any AppUpdate command is queued/executed, otherwise
+// ErrInstallDirAfterAppUpdate is returned.
+func SetInstallDir(sc *SteamCMD, path string) error {
+	if path == "" {
+		return errors.New("cannot set an empty install directory")
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return errors.Wrapf(err, "could not get absolute path of install directory %q", path)
+	}
+
+	if err = sc.AddCommandType(ForceInstallDir, absPath); err != nil {
+		return errors.Wrapf(err, "could not set install directory to %q", absPath)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package steamcmd
+
+import (
+	"github.com/pkg/errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSetInstallDir(t *testing.T) {
+	dir := t.TempDir()
+	sc := New(false)
+	if err := SetInstallDir(sc, dir); err != nil {
+		t.Fatalf("could not set install directory: %v", err)
+	}
+
+	if err := sc.AddCommandType(AppUpdate, 740, "", "", 1); err != nil {
+		t.Fatalf("could not queue AppUpdate: %v", err)
+	}
+
+	expected := []string{"+login anonymous", "+force_install_dir " + dir, "+app_update 740 -validate"}
+	if !reflect.DeepEqual(sc.commandLine(), expected) {
+		t.Errorf("expected command line %v, got %v", expected, sc.commandLine())
+	}
+
+	if err := SetInstallDir(sc, filepath.Join(dir, "other")); !errors.Is(err, ErrInstallDirAfterAppUpdate) {
+		t.Errorf("expected ErrInstallDirAfterAppUpdate when setting the install directory after AppUpdate, got %v", err)
+	}
+
+	if err := SetInstallDir(New(false), ""); err == nil {
+		t.Errorf("expected an error when setting an empty install directory")
+	}
+}
@@ -0,0 +1,43 @@
+package steamcmd
+
+import (
+	"fmt"
+	"sort"
+)
+
+// AllPaths returns the dot-separated path of every leaf field within the given parsed output of AppInfoPrint, sorted
+// lexicographically. A leaf field is a field whose value is not a map. Each path can be given to GetNestedValue. This
+// is useful when exploring the fields that are available for an app whose output structure is unknown.
+func AllPaths(output map[string]any) []string {
+	pathTypes := AllPathsWithTypes(output)
+	paths := make([]string, 0, len(pathTypes))
+	for path := range pathTypes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// AllPathsWithTypes is the same as AllPaths, but the Go type name of the value of each leaf field is also returned.
+// I.e. "string", or "int64" if the output was coerced using CoerceNumericStrings.
+func AllPathsWithTypes(output map[string]any) map[string]string {
+	pathTypes := make(map[string]string)
+	allPaths(output, "", pathTypes)
+	return pathTypes
+}
+
+// allPaths is the depth-first traversal for AllPathsWithTypes. The given prefix is the path of the given output.
+func allPaths(output map[string]any, prefix string, pathTypes map[string]string) {
+	for key, value := range output {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		if nested, ok := value.(map[string]any); ok {
+			allPaths(nested, path, pathTypes)
+		} else {
+			pathTypes[path] = fmt.Sprintf("%T", value)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package steamcmd
+
+import "fmt"
+
+func ExampleAllPaths() {
+	output := map[string]any{
+		"appid": "477160",
+		"common": map[string]any{
+			"name":  "Human: Fall Flat",
+			"genre": map[string]any{"0": "1", "1": "25"},
+		},
+		"extended": map[string]any{"isfreeapp": int64(0)},
+	}
+	fmt.Println(AllPaths(output))
+	pathTypes := AllPathsWithTypes(output)
+	fmt.Println(pathTypes["common.name"], pathTypes["extended.isfreeapp"])
+	// Output:
+	// [appid common.genre.0 common.genre.1 common.name extended.isfreeapp]
+	// string int64
+}
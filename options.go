@@ -0,0 +1,116 @@
+package steamcmd
+
+import (
+	"regexp"
+	"time"
+)
+
+// Option configures a SteamCMD when it is created using New or NewDebug.
+type Option func(sc *SteamCMD)
+
+// WithHeartbeat will start a heartbeat when an interactive SteamCMD is started. Every interval, the HeartbeatCommand
+// will be sent to the SteamCMD process to keep the connection alive. If a response to the heartbeat is not received
+// within ExpectTimeout (or the timeout given to WithHeartbeatTimeout), then the SteamCMD process is assumed to have
+// stalled and will be restarted and logged back in. Any Login, ForceInstallDir, or AppInfoUpdate commands (including the
+// one queued by WithFreshData) that have been executed are replayed when the process is restarted, so that it is in the
+// same state as before. Heartbeats are not added to the queued/executed commands, or SteamCMD.ParsedOutputs.
+func WithHeartbeat(interval time.Duration) Option {
+	return func(sc *SteamCMD) {
+		sc.heartbeatInterval = interval
+	}
+}
+
+// WithHeartbeatTimeout sets the amount of time to wait for a response to each heartbeat sent by WithHeartbeat, before
+// the SteamCMD process is assumed to have stalled. By default, this is ExpectTimeout.
+func WithHeartbeatTimeout(timeout time.Duration) Option {
+	return func(sc *SteamCMD) {
+		sc.heartbeatTimeout = timeout
+	}
+}
+
+// WithRedactSecrets will redact any text within the stdout and stderr of the SteamCMD process that matches any of the
+// given patterns before it is written to the io.Writer(s) given to NewDebug, or passed to the callback given to
+// WithLineCallback. Matches are replaced with Redacted. If a pattern has subexpressions, then only the text matched by
+// the subexpressions will be redacted. If no patterns are given, then DefaultRedactPatterns will be used. The patterns
+// are also used to redact errors that include serialised commands, and the audit log written by
+// SteamCMD.ExportAuditLog. Redaction does not apply to SteamCMD.ParsedOutputs.
+func WithRedactSecrets(patterns ...*regexp.Regexp) Option {
+	return func(sc *SteamCMD) {
+		if len(patterns) == 0 {
+			patterns = DefaultRedactPatterns
+		}
+		sc.redactPatterns = patterns
+	}
+}
+
+// WithLineCallback will call the given callback for each line of the stdout of the SteamCMD process. The line will not
+// include the trailing newline.
+func WithLineCallback(callback func(line string)) Option {
+	return func(sc *SteamCMD) {
+		sc.lineCallback = callback
+	}
+}
+
+// WithEventBufferSize sets the buffer size of the channels returned by SteamCMD.Subscribe. By default, this is
+// DefaultEventBufferSize.
+func WithEventBufferSize(n int) Option {
+	return func(sc *SteamCMD) {
+		sc.eventBufferSize = n
+	}
+}
+
+// WithPromptRegex will use the given regexp.Regexp to match the prompt of an interactive SteamCMD, instead of matching
+// the InteractivePrompt exactly. This is useful for versions of steamcmd that output the prompt with trailing spaces or
+// ANSI colour codes. DefaultPromptRegex can be used as a convenience.
+func WithPromptRegex(pattern *regexp.Regexp) Option {
+	return func(sc *SteamCMD) {
+		sc.promptRegex = pattern
+	}
+}
+
+// WithCommandTimeout sets the amount of time to wait for the prompt after a Command of the given CommandType has been
+// sent to an interactive SteamCMD. This overrides the Command.Timeout of the Command, which is ExpectTimeout for most
+// Command.
+func WithCommandTimeout(commandType CommandType, timeout time.Duration) Option {
+	return func(sc *SteamCMD) {
+		if sc.commandTimeouts == nil {
+			sc.commandTimeouts = make(map[CommandType]time.Duration)
+		}
+		sc.commandTimeouts[ResolveAlias(commandType)] = timeout
+	}
+}
+
+// WithNonInteractiveTimeout sets the maximum amount of time that a non-interactive SteamCMD process can run for when
+// SteamCMD.Close is called. If the process does not exit within this time then it is killed, and
+// ErrNonInteractiveTimeout is returned. By default, there is no timeout.
+func WithNonInteractiveTimeout(d time.Duration) Option {
+	return func(sc *SteamCMD) {
+		sc.nonInteractiveTimeout = d
+	}
+}
+
+// WithMaxParseSize sets the maximum size in bytes of the output of an AppInfoPrint command that will be parsed. If the
+// output is larger than this, then ErrOutputTooLarge is returned instead of parsing the output. By default, this is
+// DefaultMaxParseSize. A size of 0 or less will remove the limit.
+func WithMaxParseSize(bytes int) Option {
+	return func(sc *SteamCMD) {
+		sc.maxParseSize = bytes
+	}
+}
+
+// WithNumericCoercion will pass the parsed output of each AppInfoPrint command through CoerceNumericStrings, so that
+// numeric values are stored as an int64 or a float64 rather than a string.
+func WithNumericCoercion() Option {
+	return func(sc *SteamCMD) {
+		sc.numericCoercion = true
+	}
+}
+
+// WithFreshData will queue/execute the AppInfoUpdate command once before the first AppInfoPrint command of the session
+// when enabled, so that AppInfoPrint does not return stale app info from the local cache. Note that the output of the
+// AppInfoUpdate command will also be added to SteamCMD.ParsedOutputs. This can be given to BulkAppInfoPrint.
+func WithFreshData(enabled bool) Option {
+	return func(sc *SteamCMD) {
+		sc.freshData = enabled
+	}
+}
@@ -0,0 +1,119 @@
+package steamcmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/pkg/errors"
+	"os"
+)
+
+// ParsedOutput is a parsed output of a Command that has been loaded using LoadParsedOutputs.
+type ParsedOutput struct {
+	// CommandType is the CommandType of the Command that produced the output.
+	CommandType CommandType
+	// Args are the args that the Command was executed with.
+	Args []any
+	// Data is the parsed output of the Command.
+	Data any
+}
+
+// parsedOutputEntry is how a ParsedOutput is stored by SaveParsedOutputs. The CommandType is stored as its SteamCMD
+// representation so that the file does not depend on the order of the CommandType constants.
+type parsedOutputEntry struct {
+	Command string          `json:"command"`
+	Args    json.RawMessage `json:"args"`
+	Data    any             `json:"data"`
+}
+
+// SaveParsedOutputs will save the SteamCMD.ParsedOutputs of the given SteamCMD to a JSON file at the given path. The
+// CommandType and args of the Command that produced each output is saved alongside the output, so that they can be
+// checkpointed and loaded later using LoadParsedOutputs. Any args that are secret, such as the password and Steam Guard
+// code for Login, are saved as Redacted.
+func SaveParsedOutputs(sc *SteamCMD, path string) (err error) {
+	entries := make([]parsedOutputEntry, len(sc.ParsedOutputs))
+	for i, parsedOutput := range sc.ParsedOutputs {
+		entry := parsedOutputEntry{Data: parsedOutput}
+		// Each Command and its args lie at the same index as its parsed output
+		var args []any
+		if i < len(sc.commands) {
+			entry.Command = sc.commands[i].Type.String()
+			// Secret args, such as the password for Login, are redacted so that they are not written to the file
+			args = sc.commands[i].redactArgs(sc.commandArgs[i])
+		}
+
+		if args == nil {
+			args = []any{}
+		}
+		if entry.Args, err = json.Marshal(args); err != nil {
+			return errors.Wrapf(err, "could not marshal args for parsed output no. %d", i)
+		}
+		entries[i] = entry
+	}
+
+	var data []byte
+	if data, err = json.MarshalIndent(entries, "", "  "); err != nil {
+		return errors.Wrap(err, "could not marshal parsed outputs")
+	}
+
+	if err = os.WriteFile(path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "could not write parsed outputs to %q", path)
+	}
+	return
+}
+
+// LoadParsedOutputs will load the parsed outputs that were saved to the JSON file at the given path by
+// SaveParsedOutputs. Args that are numbers will be loaded using ParseArgType, so they will either be an int64 or a
+// float64.
+func LoadParsedOutputs(path string) (parsedOutputs []ParsedOutput, err error) {
+	var data []byte
+	if data, err = os.ReadFile(path); err != nil {
+		return nil, errors.Wrapf(err, "could not read parsed outputs from %q", path)
+	}
+
+	var entries []parsedOutputEntry
+	if err = json.Unmarshal(data, &entries); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal parsed outputs from %q", path)
+	}
+
+	parsedOutputs = make([]ParsedOutput, len(entries))
+	for i, entry := range entries {
+		commandType, ok := commandTypeFromSteamCMDString(entry.Command)
+		if !ok {
+			return nil, errors.Errorf("cannot find command %q for parsed output no. %d", entry.Command, i)
+		}
+
+		var args []any
+		if args, err = unmarshalArgs(entry.Args); err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal args for parsed output no. %d", i)
+		}
+
+		parsedOutputs[i] = ParsedOutput{
+			CommandType: commandType,
+			Args:        args,
+			Data:        entry.Data,
+		}
+	}
+	return
+}
+
+// unmarshalArgs will unmarshal the given JSON array of args. Args that are numbers will be parsed using ParseArgType, so
+// they will either be an int64 or a float64.
+func unmarshalArgs(data json.RawMessage) (args []any, err error) {
+	if len(data) == 0 {
+		return
+	}
+
+	// We use json.Number for args so that we don't lose any precision for large integers, like app IDs
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(&args); err != nil {
+		return nil, err
+	}
+
+	for i, arg := range args {
+		if number, ok := arg.(json.Number); ok {
+			args[i], _ = ParseArgType(number.String())
+		}
+	}
+	return
+}
@@ -0,0 +1,60 @@
+package steamcmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestSaveParsedOutputs(t *testing.T) {
+	sc := New(false)
+	for _, command := range []*CommandWithArgs{
+		NewCommandWithArgs(AppInfoPrint, 477160),
+		NewCommandWithArgs(AppInfoPrint, int64(620)),
+		NewCommandWithArgs(Login, "bob", "hunter2", "4KXJ2"),
+		NewCommandWithArgs(Quit),
+	} {
+		if err := sc.AddCommand(command.Command, command.Args...); err != nil {
+			t.Fatalf("could not queue command: %v", err)
+		}
+	}
+	sc.ParsedOutputs = []any{
+		map[string]any{"common": map[string]any{"name": "Human: Fall Flat", "gameid": "477160"}},
+		map[string]any{"common": map[string]any{"name": "Portal 2", "tags": map[string]any{"0": "1", "1": "2"}}},
+		"",
+		"",
+	}
+
+	path := filepath.Join(t.TempDir(), "parsed_outputs.json")
+	if err := SaveParsedOutputs(sc, path); err != nil {
+		t.Fatalf("could not save parsed outputs: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read saved parsed outputs: %v", err)
+	}
+
+	for _, secret := range []string{"hunter2", "4KXJ2"} {
+		if bytes.Contains(data, []byte(secret)) {
+			t.Errorf("saved parsed outputs contain secret %q", secret)
+		}
+	}
+
+	parsedOutputs, err := LoadParsedOutputs(path)
+	if err != nil {
+		t.Fatalf("could not load parsed outputs: %v", err)
+	}
+
+	expected := []ParsedOutput{
+		{AppInfoPrint, []any{int64(477160)}, sc.ParsedOutputs[0]},
+		{AppInfoPrint, []any{int64(620)}, sc.ParsedOutputs[1]},
+		{Login, []any{"bob", Redacted, Redacted}, sc.ParsedOutputs[2]},
+		{Quit, []any{}, sc.ParsedOutputs[3]},
+	}
+	if !reflect.DeepEqual(parsedOutputs, expected) {
+		t.Errorf("expected %v, got %v", expected, parsedOutputs)
+	}
+}
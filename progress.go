@@ -0,0 +1,67 @@
+package steamcmd
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The known state codes of the progress lines that are emitted by the "app_update" command.
+const (
+	UpdateStateReconfiguring    uint32 = 0x3
+	UpdateStateVerifyingInstall uint32 = 0x5
+	UpdateStatePreallocating    uint32 = 0x11
+	UpdateStateDownloading      uint32 = 0x61
+	UpdateStateVerifyingUpdate  uint32 = 0x81
+	UpdateStateCommitting       uint32 = 0x101
+)
+
+// UpdateProgress is a progress line emitted by the "app_update" command that has been parsed by ParseUpdateProgress.
+type UpdateProgress struct {
+	// State is the state code of the update. I.e. UpdateStateDownloading.
+	State uint32
+	// Description is the description of the State. I.e. "downloading".
+	Description string
+	// Percent is the percentage of the State that has been completed.
+	Percent float64
+	// Downloaded is the number of bytes that have been processed so far in the State.
+	Downloaded int64
+	// Total is the total number of bytes that will be processed in the State.
+	Total int64
+}
+
+// updateProgressPattern matches a progress line emitted by the "app_update" command. I.e.
+// "Update state (0x61) downloading, progress: 12.34 (123456789 / 999999999)".
+var updateProgressPattern = regexp.MustCompile(
+	`Update state \(0x([0-9a-fA-F]+)\) ([^,]+), progress: (\d+(?:\.\d+)?) \((\d+) / (\d+)\)`,
+)
+
+// ParseUpdateProgress will parse the given line as a progress line emitted by the "app_update" command. False is
+// returned if the line is not a progress line. This can be used with the callback given to WithLineCallback to track
+// the progress of an update.
+func ParseUpdateProgress(line string) (*UpdateProgress, bool) {
+	groups := updateProgressPattern.FindStringSubmatch(line)
+	if groups == nil {
+		return nil, false
+	}
+
+	state, err := strconv.ParseUint(groups[1], 16, 32)
+	if err != nil {
+		return nil, false
+	}
+
+	progress := UpdateProgress{
+		State:       uint32(state),
+		Description: strings.TrimSpace(groups[2]),
+	}
+	if progress.Percent, err = strconv.ParseFloat(groups[3], 64); err != nil {
+		return nil, false
+	}
+	if progress.Downloaded, err = strconv.ParseInt(groups[4], 10, 64); err != nil {
+		return nil, false
+	}
+	if progress.Total, err = strconv.ParseInt(groups[5], 10, 64); err != nil {
+		return nil, false
+	}
+	return &progress, true
+}
@@ -0,0 +1,57 @@
+package steamcmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseUpdateProgress(t *testing.T) {
+	for testNo, test := range []struct {
+		line             string
+		expectedProgress *UpdateProgress
+		expectedOk       bool
+	}{
+		{
+			" Update state (0x3) reconfiguring, progress: 0.00 (0 / 0)",
+			&UpdateProgress{UpdateStateReconfiguring, "reconfiguring", 0, 0, 0},
+			true,
+		},
+		{
+			" Update state (0x5) verifying install, progress: 45.67 (456700 / 1000000)",
+			&UpdateProgress{UpdateStateVerifyingInstall, "verifying install", 45.67, 456700, 1000000},
+			true,
+		},
+		{
+			" Update state (0x11) preallocating, progress: 99.99 (999999999 / 1000000000)",
+			&UpdateProgress{UpdateStatePreallocating, "preallocating", 99.99, 999999999, 1000000000},
+			true,
+		},
+		{
+			"Update state (0x61) downloading, progress: 12.34 (123456789 / 999999999)",
+			&UpdateProgress{UpdateStateDownloading, "downloading", 12.34, 123456789, 999999999},
+			true,
+		},
+		{
+			" Update state (0x81) verifying update, progress: 100.00 (1540366000 / 1540366000)",
+			&UpdateProgress{UpdateStateVerifyingUpdate, "verifying update", 100, 1540366000, 1540366000},
+			true,
+		},
+		{
+			" Update state (0x101) committing, progress: 50.00 (770183000 / 1540366000)",
+			&UpdateProgress{UpdateStateCommitting, "committing", 50, 770183000, 1540366000},
+			true,
+		},
+		{"Success! App '477160' fully installed.", nil, false},
+		{"Update state (0x61) downloading", nil, false},
+		{"", nil, false},
+	} {
+		progress, ok := ParseUpdateProgress(test.line)
+		if ok != test.expectedOk {
+			t.Errorf("%d: expected ok to be %t, got %t", testNo+1, test.expectedOk, ok)
+		}
+
+		if !reflect.DeepEqual(progress, test.expectedProgress) {
+			t.Errorf("%d: expected progress %+v, got %+v", testNo+1, test.expectedProgress, progress)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package steamcmd
+
+import (
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Redacted is the string that redacted secrets are replaced with.
+const Redacted = "[REDACTED]"
+
+// loginRedactPattern matches the password and Steam Guard code given to the login command. It is always used to redact
+// the login line of a SteamCMD when it is displayed, regardless of whether WithRedactSecrets was given.
+var loginRedactPattern = regexp.MustCompile(`(?i)\blogin\s+\S+\s+(\S+)(?:\s+(\S+))?`)
+
+// DefaultRedactPatterns are the patterns used by WithRedactSecrets when no patterns are given. They redact the password
+// and Steam Guard code given to the login command, the code given to set_steam_guard_code, and any Steam Guard code (5
+// characters from the alphabet used for Steam Guard codes) that follows a Steam Guard or two-factor code prompt.
+var DefaultRedactPatterns = []*regexp.Regexp{
+	loginRedactPattern,
+	regexp.MustCompile(`(?i)\bset_steam_guard_code\s+(\S+)`),
+	regexp.MustCompile(`(?i)\b(?:steam guard|two[- ]factor)\b[^\r\n:]*:\s*([2-9BCDFGHJKMNPQRTVWXY]{5})\b`),
+}
+
+// redact replaces the matches for each of the given patterns within the given string with Redacted. If a pattern has
+// subexpressions, then only the text matched by the subexpressions will be redacted.
+func redact(s string, patterns []*regexp.Regexp) string {
+	for _, pattern := range patterns {
+		var b strings.Builder
+		last := 0
+		for _, match := range pattern.FindAllStringSubmatchIndex(s, -1) {
+			// If there are no subexpressions then we redact the entire match
+			groups := match[2:]
+			if len(groups) == 0 {
+				groups = match[:2]
+			}
+
+			for i := 0; i < len(groups); i += 2 {
+				// Skip any subexpressions that were not matched
+				if groups[i] < 0 || groups[i] < last {
+					continue
+				}
+				b.WriteString(s[last:groups[i]])
+				b.WriteString(Redacted)
+				last = groups[i+1]
+			}
+		}
+		b.WriteString(s[last:])
+		s = b.String()
+	}
+	return s
+}
+
+// redactSecrets will redact the given string using the patterns given to WithRedactSecrets. If WithRedactSecrets was
+// not given, then the string is returned as is.
+func (sc *SteamCMD) redactSecrets(s string) string {
+	if len(sc.redactPatterns) == 0 {
+		return s
+	}
+	return redact(s, sc.redactPatterns)
+}
+
+// serialisedCommandRedacted returns the serialised command at the given index within serialisedCommands with the
+// password and Steam Guard code of any login, as well as anything matched by the patterns given to WithRedactSecrets,
+// redacted. This should be used whenever a serialised command is displayed.
+func (sc *SteamCMD) serialisedCommandRedacted(i int) string {
+	if i == 0 {
+		return sc.redactSecrets(redact(sc.serialisedCommands[0], []*regexp.Regexp{loginRedactPattern}))
+	}
+	return sc.redactSecrets(sc.commands[i-1].SerialiseRedacted(sc.commandArgs[i-1]...))
+}
+
+// serialisedCommandsRedacted returns all the serialisedCommands redacted using serialisedCommandRedacted.
+func (sc *SteamCMD) serialisedCommandsRedacted() []string {
+	redacted := make([]string, len(sc.serialisedCommands))
+	for i := range sc.serialisedCommands {
+		redacted[i] = sc.serialisedCommandRedacted(i)
+	}
+	return redacted
+}
+
+// redactWriter is an io.Writer that splits everything written to it into lines. Each line has any secrets matched by
+// patterns redacted, before it is written to the underlying io.Writer and passed to the callback (if there is one).
+type redactWriter struct {
+	w        io.Writer
+	patterns []*regexp.Regexp
+	callback func(line string)
+	buf      bytes.Buffer
+}
+
+// Write will write each complete line in p to the underlying io.Writer. Any incomplete line will be buffered until the
+// next call to Write or Flush.
+func (rw *redactWriter) Write(p []byte) (n int, err error) {
+	rw.buf.Write(p)
+	for {
+		i := bytes.IndexByte(rw.buf.Bytes(), '\n')
+		if i < 0 {
+			break
+		}
+
+		if err = rw.writeLine(string(rw.buf.Next(i + 1))); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// writeLine will redact the given line, then write it to the underlying io.Writer and pass it to the callback.
+func (rw *redactWriter) writeLine(line string) (err error) {
+	line = redact(line, rw.patterns)
+	if _, err = io.WriteString(rw.w, line); err != nil {
+		return
+	}
+
+	if rw.callback != nil {
+		rw.callback(strings.TrimRight(line, "\r\n"))
+	}
+	return
+}
+
+// Flush will write any incomplete line that is left in the buffer.
+func (rw *redactWriter) Flush() error {
+	if rw.buf.Len() == 0 {
+		return nil
+	}
+	line := rw.buf.String()
+	rw.buf.Reset()
+	return rw.writeLine(line)
+}
+
+// outputWriters returns the io.Writer(s) that the stdout and stderr of the SteamCMD process should be written to, in
+// addition to the console or buffer that is used to read the output of each Command. If WithRedactSecrets or
+// WithLineCallback were given, then these will be wrapped in a redactWriter that will need to be flushed using
+// flushOutputWriters once the process has exited.
+func (sc *SteamCMD) outputWriters() (stdout io.Writer, stderr io.Writer) {
+	if len(sc.redactPatterns) == 0 && sc.lineCallback == nil {
+		return sc.stdout, sc.stderr
+	}
+
+	sc.redactWriters = []*redactWriter{
+		{w: sc.stdout, patterns: sc.redactPatterns, callback: sc.lineCallback},
+		{w: sc.stderr, patterns: sc.redactPatterns},
+	}
+	return sc.redactWriters[0], sc.redactWriters[1]
+}
+
+// flushOutputWriters flushes any redactWriter(s) returned by outputWriters.
+func (sc *SteamCMD) flushOutputWriters() (err error) {
+	for _, rw := range sc.redactWriters {
+		if flushErr := rw.Flush(); flushErr != nil && err == nil {
+			err = flushErr
+		}
+	}
+	sc.redactWriters = nil
+	return
+}
@@ -0,0 +1,100 @@
+package steamcmd
+
+import (
+	"bytes"
+	"reflect"
+	"regexp"
+	"sync"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	for testNo, test := range []struct {
+		input    string
+		patterns []*regexp.Regexp
+		expected string
+	}{
+		{"+login anonymous", DefaultRedactPatterns, "+login anonymous"},
+		{"+login bob hunter2", DefaultRedactPatterns, "+login bob [REDACTED]"},
+		{"+login bob hunter2 4KXJ2", DefaultRedactPatterns, "+login bob [REDACTED] [REDACTED]"},
+		{"Steam Guard code: 4KXJ2", DefaultRedactPatterns, "Steam Guard code: [REDACTED]"},
+		{"Two-factor code:4KXJ2", DefaultRedactPatterns, "Two-factor code:[REDACTED]"},
+		{"+set_steam_guard_code 4KXJ2", DefaultRedactPatterns, "+set_steam_guard_code [REDACTED]"},
+		{"ERROR! Failed to install app '477160'", DefaultRedactPatterns, "ERROR! Failed to install app '477160'"},
+		{"AppID 34567 (23456 / 67890)", DefaultRedactPatterns, "AppID 34567 (23456 / 67890)"},
+		{"token=abc123 other=abc", []*regexp.Regexp{regexp.MustCompile(`token=\w+`)}, "[REDACTED] other=abc"},
+		{"a=1 b=2", []*regexp.Regexp{regexp.MustCompile(`\w=(\d)`)}, "a=[REDACTED] b=[REDACTED]"},
+	} {
+		if actual := redact(test.input, test.patterns); actual != test.expected {
+			t.Errorf("%d: expected %q, got %q", testNo+1, test.expected, actual)
+		}
+	}
+}
+
+func TestRedactWriter(t *testing.T) {
+	var out bytes.Buffer
+	lines := make([]string, 0)
+	rw := &redactWriter{
+		w:        &out,
+		patterns: DefaultRedactPatterns,
+		callback: func(line string) { lines = append(lines, line) },
+	}
+
+	for _, write := range []string{"Logging in user 'bob'", " with login bob hunt", "er2\r\nLogged in OK\nSteam>"} {
+		if _, err := rw.Write([]byte(write)); err != nil {
+			t.Fatalf("unexpected error whilst writing %q: %v", write, err)
+		}
+	}
+
+	if err := rw.Flush(); err != nil {
+		t.Fatalf("unexpected error whilst flushing: %v", err)
+	}
+
+	expectedOut := "Logging in user 'bob' with login bob [REDACTED]\r\nLogged in OK\nSteam>"
+	if out.String() != expectedOut {
+		t.Errorf("expected output %q, got %q", expectedOut, out.String())
+	}
+
+	expectedLines := []string{"Logging in user 'bob' with login bob [REDACTED]", "Logged in OK", "Steam>"}
+	if !reflect.DeepEqual(lines, expectedLines) {
+		t.Errorf("expected lines %q, got %q", expectedLines, lines)
+	}
+}
+
+func TestSteamCMD_Close_redactWriters(t *testing.T) {
+	// The last line is written just before the process exits and has no trailing newline, so it will only be seen if
+	// the output has been copied to the redactWriter before it is flushed.
+	fakeSteamCMD(t, `printf 'Steam>'
+while IFS= read -r line; do
+	case "$line" in
+	quit) printf 'Logging out bob hunter2\nSteam Guard code: 4KXJ2'; exit 0 ;;
+	esac
+	printf 'Steam>'
+done`)
+
+	for i := 0; i < 5; i++ {
+		var mu sync.Mutex
+		lines := make([]string, 0)
+		sc := New(true, WithRedactSecrets(
+			append([]*regexp.Regexp{regexp.MustCompile(`hunter2`)}, DefaultRedactPatterns...)...,
+		), WithLineCallback(func(line string) {
+			mu.Lock()
+			defer mu.Unlock()
+			lines = append(lines, line)
+		}))
+		if err := sc.Start(); err != nil {
+			t.Fatalf("%d: could not start SteamCMD: %v", i+1, err)
+		}
+
+		if err := sc.Close(); err != nil {
+			t.Fatalf("%d: could not close SteamCMD: %v", i+1, err)
+		}
+
+		mu.Lock()
+		expected := []string{"Steam>Logging out bob [REDACTED]", "Steam Guard code: [REDACTED]"}
+		if !reflect.DeepEqual(lines, expected) {
+			t.Errorf("%d: expected lines %q, got %q", i+1, expected, lines)
+		}
+		mu.Unlock()
+	}
+}
@@ -0,0 +1,204 @@
+package steamcmd
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"os"
+	"reflect"
+	"time"
+)
+
+var (
+	// ErrReplayMismatch is returned by a SteamCMD created using NewReplaySession when a Command is queued/executed that
+	// does not match the next Command within the recording.
+	ErrReplayMismatch = errors.New("command does not match the recording")
+	// ErrReplayExhausted is returned by a SteamCMD created using NewReplaySession when a Command is queued/executed after
+	// all the Command within the recording have been replayed.
+	ErrReplayExhausted = errors.New("no more commands in the recording")
+)
+
+// RecordedCommand is a Command that was executed by a SteamCMD that is being recorded by a RecordedSession.
+type RecordedCommand struct {
+	// CommandType is the CommandType of the executed Command.
+	CommandType CommandType
+	// Args are the args that the Command was executed with.
+	Args []any
+	// Output is the raw output of the Command. In non-interactive mode, this will be the output of the entire SteamCMD
+	// process.
+	Output []byte
+	// ParsedOutput is the output of the Command after it was parsed using Command.Parse.
+	ParsedOutput any
+}
+
+// recordedCommandEntry is how a RecordedCommand is stored by RecordedSession.Save.
+type recordedCommandEntry struct {
+	Command      string          `json:"command"`
+	Args         json.RawMessage `json:"args"`
+	Output       []byte          `json:"output"`
+	ParsedOutput any             `json:"parsed_output"`
+}
+
+// RecordedSession records each Command that is executed by a live SteamCMD, so that the session can be saved and then
+// replayed later using NewReplaySession. This is useful for testing code that uses SteamCMD without needing the
+// steamcmd binary.
+type RecordedSession struct {
+	// Commands are the RecordedCommand that have been executed so far.
+	Commands []RecordedCommand
+}
+
+// NewRecorder will start recording each Command that is executed by the given SteamCMD. This should be called before
+// any Command are queued/executed.
+func NewRecorder(sc *SteamCMD) *RecordedSession {
+	rs := &RecordedSession{Commands: make([]RecordedCommand, 0)}
+	sc.recorder = rs
+	return rs
+}
+
+// record adds a RecordedCommand for the given Command to the RecordedSession.
+func (rs *RecordedSession) record(command *Command, args []any, output []byte, parsedOutput any) {
+	rs.Commands = append(rs.Commands, RecordedCommand{
+		CommandType:  command.Type,
+		Args:         args,
+		Output:       append([]byte{}, output...),
+		ParsedOutput: parsedOutput,
+	})
+}
+
+// Save will write the RecordedSession to a JSON file at the given path.
+func (rs *RecordedSession) Save(path string) (err error) {
+	entries := make([]recordedCommandEntry, len(rs.Commands))
+	for i, command := range rs.Commands {
+		args := command.Args
+		if args == nil {
+			args = []any{}
+		}
+
+		entries[i] = recordedCommandEntry{
+			Command:      command.CommandType.String(),
+			Output:       command.Output,
+			ParsedOutput: command.ParsedOutput,
+		}
+		if entries[i].Args, err = json.Marshal(args); err != nil {
+			return errors.Wrapf(err, "could not marshal args for recorded command no. %d", i)
+		}
+	}
+
+	var data []byte
+	if data, err = json.MarshalIndent(entries, "", "  "); err != nil {
+		return errors.Wrap(err, "could not marshal recorded session")
+	}
+
+	if err = os.WriteFile(path, data, 0o644); err != nil {
+		return errors.Wrapf(err, "could not write recorded session to %q", path)
+	}
+	return
+}
+
+// LoadRecordedSession will load a RecordedSession that was saved to the JSON file at the given path by
+// RecordedSession.Save.
+func LoadRecordedSession(path string) (rs *RecordedSession, err error) {
+	var data []byte
+	if data, err = os.ReadFile(path); err != nil {
+		return nil, errors.Wrapf(err, "could not read recorded session from %q", path)
+	}
+
+	var entries []recordedCommandEntry
+	if err = json.Unmarshal(data, &entries); err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal recorded session from %q", path)
+	}
+
+	rs = &RecordedSession{Commands: make([]RecordedCommand, len(entries))}
+	for i, entry := range entries {
+		commandType, ok := commandTypeFromSteamCMDString(entry.Command)
+		if !ok {
+			return nil, errors.Errorf("cannot find command %q for recorded command no. %d", entry.Command, i)
+		}
+
+		var args []any
+		if args, err = unmarshalArgs(entry.Args); err != nil {
+			return nil, errors.Wrapf(err, "could not unmarshal args for recorded command no. %d", i)
+		}
+
+		rs.Commands[i] = RecordedCommand{
+			CommandType:  commandType,
+			Args:         args,
+			Output:       entry.Output,
+			ParsedOutput: entry.ParsedOutput,
+		}
+	}
+	return
+}
+
+// NewReplaySession loads the RecordedSession that was saved to the JSON file at the given path, and returns a SteamCMD
+// that will replay it. The returned SteamCMD behaves like an interactive SteamCMD, but it does not start the steamcmd
+// binary. Instead, each Command that is queued/executed must match the next RecordedCommand in the recording, and its
+// output will be parsed from the RecordedCommand.Output. If there is no recorded output then the
+// RecordedCommand.ParsedOutput will be used instead.
+func NewReplaySession(path string, opts ...Option) (*SteamCMD, error) {
+	rs, err := LoadRecordedSession(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sc := New(true, opts...)
+	sc.replay = rs.Commands
+	return sc, nil
+}
+
+// executeReplay will execute the given Command using the next RecordedCommand within the recording that is being
+// replayed. If the Command is Quit and the next RecordedCommand is not, then the Quit command will be executed with no
+// output, as Quit is not always recorded.
+func (sc *SteamCMD) executeReplay(command *Command, args ...any) (err error) {
+	trace := ExecutionTrace{CommandType: command.Type, Args: command.redactArgs(args)}
+	startTime := time.Now()
+	defer func() {
+		trace.setTimes(startTime, time.Now())
+		trace.Err = err
+		sc.traces = append(sc.traces, trace)
+		sc.publish(EventCommandExecuted, trace)
+	}()
+
+	if command.Type == Quit && (len(sc.replay) == 0 || sc.replay[0].CommandType != Quit) {
+		sc.ParsedOutputs = append(sc.ParsedOutputs, "")
+		return
+	}
+
+	if len(sc.replay) == 0 {
+		return errors.Wrapf(ErrReplayExhausted, "cannot replay command %q", command.SerialiseRedacted(args...))
+	}
+
+	recorded := sc.replay[0]
+	// The args of recorded commands have their secrets redacted, so we also redact the given args before comparing them
+	redactedArgs := command.redactArgs(args)
+	if recorded.CommandType != command.Type ||
+		!reflect.DeepEqual(normaliseArgs(recorded.Args), normaliseArgs(redactedArgs)) {
+		return errors.Wrapf(
+			ErrReplayMismatch, "expected command %q, got %q",
+			NewCommandWithArgs(recorded.CommandType).Command.Serialise(recorded.Args...),
+			command.Serialise(redactedArgs...),
+		)
+	}
+	sc.replay = sc.replay[1:]
+
+	var parsedOutput any
+	if parsedOutput = recorded.ParsedOutput; len(recorded.Output) > 0 {
+		if parsedOutput, err = sc.parseOutput(command, recorded.Output); err != nil {
+			err = errors.Wrapf(err, "could not parse recorded output for command %q", command.Serialise(redactedArgs...))
+		}
+	}
+	sc.ParsedOutputs = append(sc.ParsedOutputs, parsedOutput)
+	return
+}
+
+// normaliseArgs converts each arg to the type that it would have after being saved and then loaded by
+// unmarshalArgs, so that args can be compared regardless of whether they have been loaded from a recording.
+func normaliseArgs(args []any) []any {
+	normalised := make([]any, len(args))
+	for i, arg := range args {
+		normalised[i] = arg
+		if Number.DefaultValidator(arg) {
+			normalised[i], _ = ParseArgType(Number.DefaultSerialiser(arg))
+		}
+	}
+	return normalised
+}
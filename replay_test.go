@@ -0,0 +1,207 @@
+package steamcmd
+
+import (
+	"github.com/pkg/errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const sampleAppInfoPrintOutput = `AppID : 477160, change number : 16411497/0, last change : Fri Oct  7 14:32:33 2022
+"477160"
+{
+	"common"
+	{
+		"name"		"Human: Fall Flat"
+		"type"		"Game"
+	}
+}`
+
+func TestNewReplaySession(t *testing.T) {
+	fakeSteamCMD(t, "cat <<'EOF'\n"+sampleAppInfoPrintOutput+"\nEOF")
+
+	// Record a session using the fake steamcmd binary
+	recorded := New(false)
+	rs := NewRecorder(recorded)
+	if err := recorded.Flow(NewCommandWithArgs(AppInfoPrint, 477160), NewCommandWithArgs(Quit)); err != nil {
+		t.Fatalf("could not run recorded flow: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := rs.Save(path); err != nil {
+		t.Fatalf("could not save recorded session: %v", err)
+	}
+
+	// Then replay it
+	replayed, err := NewReplaySession(path)
+	if err != nil {
+		t.Fatalf("could not create replay session: %v", err)
+	}
+
+	if err = replayed.Flow(NewCommandWithArgs(AppInfoPrint, 477160), NewCommandWithArgs(Quit)); err != nil {
+		t.Fatalf("could not run replayed flow: %v", err)
+	}
+
+	if !reflect.DeepEqual(replayed.ParsedOutputs, recorded.ParsedOutputs) {
+		t.Errorf("expected replayed parsed outputs %v, got %v", recorded.ParsedOutputs, replayed.ParsedOutputs)
+	}
+
+	if name, _ := AppName(replayed.ParsedOutputs[0].(map[string]any)); name != "Human: Fall Flat" {
+		t.Errorf("expected name %q, got %q", "Human: Fall Flat", name)
+	}
+
+	// Replaying a different command should fail
+	if replayed, err = NewReplaySession(path); err != nil {
+		t.Fatalf("could not create replay session: %v", err)
+	}
+
+	if err = replayed.Flow(NewCommandWithArgs(AppInfoPrint, 620)); !errors.Is(err, ErrReplayMismatch) {
+		t.Errorf("expected ErrReplayMismatch, got %v", err)
+	}
+
+	// The failed command should still be in the history
+	if history := replayed.CommandHistory(); len(history) == 0 || !errors.Is(history[0].Err, ErrReplayMismatch) {
+		t.Errorf("expected the history to contain the failed command, got %v", history)
+	}
+}
+
+func TestSteamCMD_FlowWithRequest(t *testing.T) {
+	rs := &RecordedSession{Commands: []RecordedCommand{
+		{CommandType: AppInfoRequest, Args: []any{477160}, Output: []byte("Requesting appinfo update for 477160")},
+		{CommandType: AppInfoPrint, Args: []any{477160}, Output: []byte(sampleAppInfoPrintOutput)},
+	}}
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := rs.Save(path); err != nil {
+		t.Fatalf("could not save recorded session: %v", err)
+	}
+
+	sc, err := NewReplaySession(path)
+	if err != nil {
+		t.Fatalf("could not create replay session: %v", err)
+	}
+
+	var output map[string]any
+	if output, err = sc.FlowWithRequest(477160); err != nil {
+		t.Fatalf("could not run flow with request: %v", err)
+	}
+
+	if name, _ := AppName(output); name != "Human: Fall Flat" {
+		t.Errorf("expected name %q, got %q", "Human: Fall Flat", name)
+	}
+
+	if !NewCommandWithArgs(AppInfoRequest).Command.ValidateOutput(1, []byte("Requesting appinfo update for 477160")) {
+		t.Errorf("expected AppInfoRequest output to be valid")
+	}
+}
+
+func TestSteamCMD_FlowWithFreshInfo(t *testing.T) {
+	rs := &RecordedSession{Commands: []RecordedCommand{
+		{CommandType: AppInfoUpdate, Args: []any{1}, Output: []byte("app_info_update 1")},
+		{CommandType: AppInfoPrint, Args: []any{477160}, Output: []byte(sampleAppInfoPrintOutput)},
+	}}
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := rs.Save(path); err != nil {
+		t.Fatalf("could not save recorded session: %v", err)
+	}
+
+	sc, err := NewReplaySession(path)
+	if err != nil {
+		t.Fatalf("could not create replay session: %v", err)
+	}
+
+	var output map[string]any
+	if output, err = sc.FlowWithFreshInfo(477160); err != nil {
+		t.Fatalf("could not run flow with fresh info: %v", err)
+	}
+
+	if sc.freshData {
+		t.Errorf("expected fresh data to be restored after the flow")
+	}
+
+	if name, _ := AppName(output); name != "Human: Fall Flat" {
+		t.Errorf("expected name %q, got %q", "Human: Fall Flat", name)
+	}
+}
+
+func TestWithFreshData(t *testing.T) {
+	sc := New(false, WithFreshData(true))
+	for _, appID := range []int{477160, 620} {
+		if err := sc.AddCommandType(AppInfoPrint, appID); err != nil {
+			t.Fatalf("could not queue AppInfoPrint for %d: %v", appID, err)
+		}
+	}
+
+	expected := []string{"+login anonymous", "+app_info_update 1", "+app_info_print 477160", "+app_info_print 620"}
+	if !reflect.DeepEqual(sc.serialisedCommands, expected) {
+		t.Errorf("expected serialised commands %v, got %v", expected, sc.serialisedCommands)
+	}
+}
+
+func TestWithMaxParseSize(t *testing.T) {
+	rs := &RecordedSession{Commands: []RecordedCommand{
+		{CommandType: AppInfoPrint, Args: []any{477160}, Output: []byte(sampleAppInfoPrintOutput)},
+	}}
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := rs.Save(path); err != nil {
+		t.Fatalf("could not save recorded session: %v", err)
+	}
+
+	for testNo, test := range []struct {
+		opts        []Option
+		expectedErr bool
+	}{
+		{nil, false},
+		{[]Option{WithMaxParseSize(len(sampleAppInfoPrintOutput))}, false},
+		{[]Option{WithMaxParseSize(len(sampleAppInfoPrintOutput) - 1)}, true},
+		{[]Option{WithMaxParseSize(0)}, false},
+	} {
+		sc, err := NewReplaySession(path, test.opts...)
+		if err != nil {
+			t.Fatalf("%d: could not create replay session: %v", testNo+1, err)
+		}
+
+		err = sc.Flow(NewCommandWithArgs(AppInfoPrint, 477160))
+		var tooLargeErr ErrOutputTooLarge
+		if isTooLarge := errors.As(err, &tooLargeErr); isTooLarge != test.expectedErr {
+			t.Errorf("%d: expected ErrOutputTooLarge to be %t, got %v", testNo+1, test.expectedErr, err)
+		}
+	}
+}
+
+func TestWithNumericCoercion(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/app_info_print_477160.txt")
+	if err != nil {
+		t.Fatalf("could not read fixture: %v", err)
+	}
+
+	rs := &RecordedSession{Commands: []RecordedCommand{
+		{CommandType: AppInfoPrint, Args: []any{477160}, Output: fixture},
+	}}
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err = rs.Save(path); err != nil {
+		t.Fatalf("could not save recorded session: %v", err)
+	}
+
+	var sc *SteamCMD
+	if sc, err = NewReplaySession(path, WithNumericCoercion()); err != nil {
+		t.Fatalf("could not create replay session: %v", err)
+	}
+
+	if err = sc.Flow(NewCommandWithArgs(AppInfoPrint, 477160)); err != nil {
+		t.Fatalf("could not run replayed flow: %v", err)
+	}
+
+	output := sc.ParsedOutputs[0].(map[string]any)
+	if appID := output["appid"]; appID != int64(477160) {
+		t.Errorf("expected appid to be coerced to int64(477160), got %v (%T)", appID, appID)
+	}
+
+	if name, _ := AppName(output); name != "Human: Fall Flat" {
+		t.Errorf("expected name %q, got %q", "Human: Fall Flat", name)
+	}
+}
@@ -0,0 +1,94 @@
+package steamcmd
+
+import (
+	"bufio"
+	"github.com/pkg/errors"
+	"io"
+	"strings"
+)
+
+// scriptComment is the prefix used for comments in SteamCMD scripts. This is the same as in the scripts that can be run
+// using the steamcmd "runscript" command.
+const scriptComment = "//"
+
+// scriptLines will read each line from the given io.Reader and call the given function for each non-empty line with
+// comments removed. The line number is also given to the function so that errors can be reported.
+func scriptLines(r io.Reader, f func(lineNo int, line string) error) (err error) {
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line, _, _ := strings.Cut(scanner.Text(), scriptComment)
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+
+		if err = f(lineNo, line); err != nil {
+			return
+		}
+	}
+	return errors.Wrap(scanner.Err(), "could not read script")
+}
+
+// AddCommandsFromReader will read each line from the given io.Reader, deserialise the line to a Command using
+// DeserialiseCommandLine, then add the Command using SteamCMD.AddCommand. Empty lines and comments (anything after a
+// "//") are ignored.
+func (sc *SteamCMD) AddCommandsFromReader(r io.Reader) error {
+	return scriptLines(r, func(lineNo int, line string) error {
+		command, err := DeserialiseCommandLine(line)
+		if err != nil {
+			return errors.Wrapf(err, "could not deserialise line %d", lineNo)
+		}
+
+		if err = sc.AddCommand(command.Command, command.Args...); err != nil {
+			return errors.Wrapf(err, "could not queue/execute command on line %d", lineNo)
+		}
+		return nil
+	})
+}
+
+// NewFromScript creates a new non-interactive SteamCMD from the given script. The returned SteamCMD will have all the
+// commands from the script queued, so it can be run by calling SteamCMD.Close. The script format is similar to the
+// format of the scripts that can be run by steamcmd's "runscript" command:
+//
+//   - Each command is on its own line and can optionally be prefixed with a "+".
+//   - Empty lines and comments (anything after a "//") are ignored.
+//   - Lines starting with "@" are directives that are passed to steamcmd before logging in.
+//   - A "login" line will replace the default anonymous login. It must come before any commands.
+//   - A "quit" line is optional as it will be added when SteamCMD.Close is called.
+//
+// For example:
+//
+//	// Stop steamcmd if any command fails
+//	@ShutdownOnFailedCommand 1
+//	login anonymous
+//	app_info_print 477160
+//	quit
+func NewFromScript(script string) (sc *SteamCMD, err error) {
+	sc = New(false)
+	var commandLines strings.Builder
+	if err = scriptLines(strings.NewReader(script), func(lineNo int, line string) error {
+		line = strings.TrimPrefix(line, "+")
+		switch {
+		case strings.HasPrefix(line, "@"):
+			sc.directives = append(sc.directives, "+"+line)
+		case strings.Fields(line)[0] == "login":
+			if commandLines.Len() > 0 {
+				return errors.Errorf("login on line %d must come before any commands", lineNo)
+			}
+			fields := strings.Fields(line)
+			sc.serialisedCommands[0] = "+" + strings.Join(fields, " ")
+			sc.loggedIn = len(fields) > 1 && !isAnonymousLogin(fields[1])
+		default:
+			commandLines.WriteString(line + "\n")
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not parse SteamCMD script")
+	}
+
+	if err = sc.AddCommandsFromReader(strings.NewReader(commandLines.String())); err != nil {
+		return nil, errors.Wrap(err, "could not add commands from SteamCMD script")
+	}
+	return
+}
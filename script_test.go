@@ -0,0 +1,76 @@
+package steamcmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewFromScript(t *testing.T) {
+	for testNo, test := range []struct {
+		script      string
+		expected    []string
+		expectedErr bool
+	}{
+		{
+			script: `
+				// Stop steamcmd if any command fails
+				@ShutdownOnFailedCommand 1
+				login anonymous
+				app_info_print 477160 // Human: Fall Flat
+				+app_info_print 1091500
+				quit
+			`,
+			expected: []string{
+				"+@ShutdownOnFailedCommand 1",
+				"+login anonymous",
+				"+app_info_print 477160",
+				"+app_info_print 1091500",
+				"+quit",
+			},
+		},
+		{
+			script: "app_info_print 477160",
+			expected: []string{
+				"+login anonymous",
+				"+app_info_print 477160",
+			},
+		},
+		{
+			script:      "app_info_print 477160\nlogin anonymous",
+			expectedErr: true,
+		},
+		{
+			script:      "app_info_print hello",
+			expectedErr: true,
+		},
+		{
+			script:      "not_a_command",
+			expectedErr: true,
+		},
+		{
+			script:      "quit\napp_info_print 477160",
+			expectedErr: true,
+		},
+	} {
+		sc, err := NewFromScript(test.script)
+		if test.expectedErr {
+			if err == nil {
+				t.Errorf("%d: expected an error, got nil", testNo+1)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", testNo+1, err)
+			continue
+		}
+
+		if !reflect.DeepEqual(sc.commandLine(), test.expected) {
+			t.Errorf(
+				"%d: expected command line %q, got %q",
+				testNo+1, strings.Join(test.expected, " "), strings.Join(sc.commandLine(), " "),
+			)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package steamcmd
+
+import (
+	"regexp"
+	"sort"
+)
+
+// FieldMatch is a field within the parsed output of AppInfoPrint that matched the query given to SearchOutput or
+// SearchOutputRegex.
+type FieldMatch struct {
+	// Path is the dot-separated path to the field, which can be given to GetNestedValue.
+	Path string
+	// Key is the key of the field.
+	Key string
+	// Value is the value of the field. This can be a nested map.
+	Value any
+}
+
+// SearchOutput searches the keys and values of the given parsed output of AppInfoPrint for the given query. The query
+// is matched case-insensitively as a substring. See SearchOutputRegex for more details.
+func SearchOutput(output map[string]any, query string) []FieldMatch {
+	return SearchOutputRegex(output, regexp.MustCompile("(?i)"+regexp.QuoteMeta(query)))
+}
+
+// SearchOutputRegex searches the keys and values of the given parsed output of AppInfoPrint for the given pattern. The
+// output is searched depth-first, with the keys of each map being visited in sorted order. A FieldMatch is returned for
+// each field whose key, or non-map value, matches the pattern. This is useful to find out which fields are available
+// for an app when debugging.
+func SearchOutputRegex(output map[string]any, pattern *regexp.Regexp) []FieldMatch {
+	matches := make([]FieldMatch, 0)
+	searchOutput(output, "", pattern, &matches)
+	return matches
+}
+
+// searchOutput is the depth-first search for SearchOutputRegex. The given prefix is the path of the given output.
+func searchOutput(output map[string]any, prefix string, pattern *regexp.Regexp, matches *[]FieldMatch) {
+	keys := make([]string, 0, len(output))
+	for key := range output {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		value := output[key]
+		nested, isMap := value.(map[string]any)
+		if pattern.MatchString(key) || (!isMap && pattern.MatchString(toString(value))) {
+			*matches = append(*matches, FieldMatch{Path: path, Key: key, Value: value})
+		}
+
+		if isMap {
+			searchOutput(nested, path, pattern, matches)
+		}
+	}
+}
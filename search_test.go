@@ -0,0 +1,34 @@
+package steamcmd
+
+import (
+	"fmt"
+	"regexp"
+)
+
+func ExampleSearchOutput() {
+	output := map[string]any{
+		"appid": "477160",
+		"common": map[string]any{
+			"name":               "Human: Fall Flat",
+			"metacritic_name":    "Human: Fall Flat",
+			"metacritic_score":   "70",
+			"metacritic_fullurl": "https://www.metacritic.com/game/pc/human-fall-flat",
+		},
+		"extended": map[string]any{"homepage": "http://www.humanfallflat.com"},
+	}
+	for _, match := range SearchOutput(output, "FALL") {
+		fmt.Println(match.Path, match.Value)
+	}
+	fmt.Println()
+	for _, match := range SearchOutputRegex(output, regexp.MustCompile(`^metacritic_(score|fullurl)$`)) {
+		fmt.Println(match.Path, match.Value)
+	}
+	// Output:
+	// common.metacritic_fullurl https://www.metacritic.com/game/pc/human-fall-flat
+	// common.metacritic_name Human: Fall Flat
+	// common.name Human: Fall Flat
+	// extended.homepage http://www.humanfallflat.com
+	//
+	// common.metacritic_fullurl https://www.metacritic.com/game/pc/human-fall-flat
+	// common.metacritic_score 70
+}
@@ -0,0 +1,73 @@
+package steamcmd
+
+import (
+	"encoding/json"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+)
+
+// AppInfoToJSON marshals the given parsed output of AppInfoPrint to indented JSON. This is the same format that is
+// used for each output by SaveParsedOutputs.
+func AppInfoToJSON(output map[string]any) ([]byte, error) {
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal app info to JSON")
+	}
+	return data, nil
+}
+
+// AppInfoToYAML marshals the given parsed output of AppInfoPrint to YAML using yaml.Marshal. Keys are sorted, and
+// strings that could be mistaken for other types (such as "1" and "true") are quoted, so that they keep their original
+// types when they are read back.
+func AppInfoToYAML(output map[string]any) ([]byte, error) {
+	data, err := yaml.Marshal(output)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not marshal app info to YAML")
+	}
+	return data, nil
+}
+
+// AppInfoToYAMLFile marshals the given parsed output of AppInfoPrint to YAML using AppInfoToYAML, then writes it to the
+// file at the given path. The file is written atomically, so the file at the given path will never contain a
+// partially written output.
+func AppInfoToYAMLFile(output map[string]any, path string) error {
+	data, err := AppInfoToYAML(output)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(path, data, 0o644)
+}
+
+// writeFileAtomic writes the given data to a temporary file within the same directory as the given path, then renames
+// the temporary file to the given path.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) (err error) {
+	var tmp *os.File
+	if tmp, err = os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp"); err != nil {
+		return errors.Wrapf(err, "could not create temporary file for %q", path)
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		return errors.Wrapf(err, "could not write to temporary file for %q", path)
+	}
+	if err = tmp.Sync(); err != nil {
+		return errors.Wrapf(err, "could not sync temporary file for %q", path)
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrapf(err, "could not close temporary file for %q", path)
+	}
+	if err = os.Chmod(tmp.Name(), perm); err != nil {
+		return errors.Wrapf(err, "could not set permissions of temporary file for %q", path)
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return errors.Wrapf(err, "could not rename temporary file to %q", path)
+	}
+	return
+}
@@ -0,0 +1,89 @@
+package steamcmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func ExampleAppInfoToYAML() {
+	output := map[string]any{
+		"appid": "477160",
+		"common": map[string]any{
+			"name":     "Human: Fall Flat",
+			"type":     "Game",
+			"oslist":   "windows,macos,linux",
+			"category": map[string]any{},
+		},
+		"tags": []any{"Puzzle", int64(1), true},
+	}
+	data, err := AppInfoToYAML(output)
+	fmt.Print(string(data))
+	fmt.Println(err)
+	// Output:
+	// appid: "477160"
+	// common:
+	//     category: {}
+	//     name: 'Human: Fall Flat'
+	//     oslist: windows,macos,linux
+	//     type: Game
+	// tags:
+	//     - Puzzle
+	//     - 1
+	//     - true
+	// <nil>
+}
+
+func TestAppInfoToYAMLFile(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join("testdata", "app_info_print_477160.txt"))
+	if err != nil {
+		t.Fatalf("could not read fixture: %v", err)
+	}
+
+	var parsedOutput any
+	if parsedOutput, err = parseAppInfoPrint(b); err != nil {
+		t.Fatalf("could not parse fixture: %v", err)
+	}
+	output := parsedOutput.(map[string]any)
+
+	path := filepath.Join(t.TempDir(), "477160.yaml")
+	if err = AppInfoToYAMLFile(output, path); err != nil {
+		t.Fatalf("could not write YAML file: %v", err)
+	}
+
+	var entries []os.DirEntry
+	if entries, err = os.ReadDir(filepath.Dir(path)); err != nil {
+		t.Fatalf("could not read temp dir: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name() != "477160.yaml" {
+		t.Errorf("expected only 477160.yaml to be in the temp dir, got %v", entries)
+	}
+
+	if b, err = os.ReadFile(path); err != nil {
+		t.Fatalf("could not read YAML file: %v", err)
+	}
+
+	var yamlRoundTripped map[string]any
+	if err = yaml.Unmarshal(b, &yamlRoundTripped); err != nil {
+		t.Fatalf("could not unmarshal YAML file: %v", err)
+	}
+	if !reflect.DeepEqual(yamlRoundTripped, output) {
+		t.Errorf("YAML round trip of app info does not match the original output")
+	}
+
+	var jsonData []byte
+	if jsonData, err = AppInfoToJSON(output); err != nil {
+		t.Fatalf("could not marshal to JSON: %v", err)
+	}
+	var roundTripped map[string]any
+	if err = json.Unmarshal(jsonData, &roundTripped); err != nil {
+		t.Fatalf("could not unmarshal JSON: %v", err)
+	}
+	if !reflect.DeepEqual(roundTripped, output) {
+		t.Errorf("JSON round trip of app info does not match the original output")
+	}
+}
@@ -0,0 +1,69 @@
+package steamcmd
+
+import (
+	"github.com/andygello555/agem"
+	"github.com/pkg/errors"
+	"sync"
+)
+
+// splitCommands divides the given CommandWithArgs into at most n contiguous, non-overlapping groups of roughly equal
+// size. The order of the CommandWithArgs is preserved across the groups.
+func splitCommands(n int, cmds []*CommandWithArgs) [][]*CommandWithArgs {
+	if n > len(cmds) {
+		n = len(cmds)
+	}
+
+	groups := make([][]*CommandWithArgs, n)
+	start := 0
+	for i := range groups {
+		// The first len(cmds) % n groups will take one extra command
+		size := len(cmds) / n
+		if i < len(cmds)%n {
+			size++
+		}
+		groups[i] = cmds[start : start+size]
+		start += size
+	}
+	return groups
+}
+
+// SplitFlow divides the given CommandWithArgs into n groups, then runs each group concurrently using its own
+// non-interactive SteamCMD. The SteamCMD.ParsedOutputs of each group are returned in the same order as the groups, so
+// flattening the result will give the parsed outputs in the same order as the given CommandWithArgs. The Quit command
+// should not be given, as it is added to each group automatically, and its output is not included in the result.
+//
+// Errors from each group are merged together. The parsed outputs of the groups that succeeded are returned even if
+// other groups fail, in which case the parsed outputs of the failed groups will be nil.
+func SplitFlow(n int, cmds ...*CommandWithArgs) ([][]any, error) {
+	if n <= 0 {
+		return nil, errors.Errorf("cannot split flow into %d groups", n)
+	}
+
+	if err := ValidateFlow(cmds...); err != nil {
+		return nil, errors.Wrap(err, "invalid flow")
+	}
+
+	// Each group will quit on its own
+	if len(cmds) > 0 && cmds[len(cmds)-1].Command.Type == Quit {
+		cmds = cmds[:len(cmds)-1]
+	}
+
+	groups := splitCommands(n, cmds)
+	results := make([][]any, len(groups))
+	errs := make([]error, len(groups))
+	var wg sync.WaitGroup
+	for i, group := range groups {
+		wg.Add(1)
+		go func(i int, group []*CommandWithArgs) {
+			defer wg.Done()
+			sc := New(false)
+			if err := sc.Flow(group...); err != nil {
+				errs[i] = errors.Wrapf(err, "group no. %d failed", i)
+				return
+			}
+			results[i] = sc.ParsedOutputs[:len(group)]
+		}(i, group)
+	}
+	wg.Wait()
+	return results, agem.MergeErrors(errs...)
+}
@@ -0,0 +1,87 @@
+package steamcmd
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// fakeAppInfoPrintScript is the body of a fake steamcmd that prints a different app info object for each
+// "+app_info_print <appID>" arg. The name of each app is "App <appID>".
+const fakeAppInfoPrintScript = `for arg in "$@"; do
+	case "$arg" in
+	"+app_info_print "*)
+		id="${arg#+app_info_print }"
+		printf 'AppID : %s, change number : 16411497/0, last change : Fri Oct  7 14:32:33 2022\n' "$id"
+		printf '"%s"\n{\n\t"common"\n\t{\n\t\t"name"\t\t"App %s"\n\t}\n}\n' "$id" "$id"
+		;;
+	esac
+done`
+
+func TestSplitFlow(t *testing.T) {
+	fakeSteamCMD(t, fakeAppInfoPrintScript)
+
+	cmds := []*CommandWithArgs{
+		NewCommandWithArgs(AppInfoPrint, 477160),
+		NewCommandWithArgs(AppInfoPrint, 620),
+		NewCommandWithArgs(AppInfoPrint, 730),
+		NewCommandWithArgs(AppInfoPrint, 440),
+		NewCommandWithArgs(AppInfoPrint, 570),
+	}
+
+	for testNo, test := range []struct {
+		n             int
+		expectedSizes []int
+	}{
+		{1, []int{5}},
+		{2, []int{3, 2}},
+		{3, []int{2, 2, 1}},
+		{10, []int{1, 1, 1, 1, 1}},
+	} {
+		results, err := SplitFlow(test.n, cmds...)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", testNo+1, err)
+			continue
+		}
+
+		sizes := make([]int, len(results))
+		cmdNo := 0
+		for i, result := range results {
+			sizes[i] = len(result)
+			for _, output := range result {
+				expectedName := fmt.Sprintf("App %d", cmds[cmdNo].Args[0])
+				if name, _ := AppName(output.(map[string]any)); name != expectedName {
+					t.Errorf("%d: expected name %q for command no. %d, got %q", testNo+1, expectedName, cmdNo, name)
+				}
+				cmdNo++
+			}
+		}
+
+		if !reflect.DeepEqual(sizes, test.expectedSizes) {
+			t.Errorf("%d: expected group sizes %v, got %v", testNo+1, test.expectedSizes, sizes)
+		}
+	}
+
+	if _, err := SplitFlow(0, cmds...); err == nil {
+		t.Errorf("expected an error when splitting a flow into 0 groups")
+	}
+}
+
+func TestSplitFlowPartialFailure(t *testing.T) {
+	// The fake steamcmd fails for the app ID 620 only
+	fakeSteamCMD(t, "case \"$*\" in *\"app_info_print 620\"*) exit 5;; esac\ncat <<'EOF'\n"+sampleAppInfoPrintOutput+"\nEOF")
+
+	results, err := SplitFlow(
+		2,
+		NewCommandWithArgs(AppInfoPrint, 477160),
+		NewCommandWithArgs(AppInfoPrint, 620),
+		NewCommandWithArgs(Quit),
+	)
+	if err == nil {
+		t.Errorf("expected an error for the failed group")
+	}
+
+	if len(results) != 2 || len(results[0]) != 1 || results[1] != nil {
+		t.Errorf("expected results for the first group only, got %v", results)
+	}
+}
@@ -0,0 +1,152 @@
+package steamcmd
+
+import (
+	"github.com/pkg/errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// The known flags of the install state of an app, as found in the StateFlags of an AppStatusResult. These are the same
+// as the "StateFlags" within the appmanifest files of installed apps.
+const (
+	AppStateUninstalled    = 0x1
+	AppStateUpdateRequired = 0x2
+	AppStateFullyInstalled = 0x4
+	AppStateFilesMissing   = 0x20
+	AppStateAppRunning     = 0x40
+	AppStateFilesCorrupt   = 0x80
+	AppStateUpdateRunning  = 0x100
+	AppStateUpdatePaused   = 0x200
+	AppStateUpdateStarted  = 0x400
+	AppStateUninstalling   = 0x800
+	AppStateValidating     = 0x20000
+	AppStateDownloading    = 0x100000
+)
+
+// appStateNames maps the lowercase names of the install states in the output of the AppStatus command to their flags.
+var appStateNames = map[string]int{
+	"uninstalled":     AppStateUninstalled,
+	"update required": AppStateUpdateRequired,
+	"fully installed": AppStateFullyInstalled,
+	"files missing":   AppStateFilesMissing,
+	"app running":     AppStateAppRunning,
+	"files corrupt":   AppStateFilesCorrupt,
+	"update running":  AppStateUpdateRunning,
+	"update paused":   AppStateUpdatePaused,
+	"update started":  AppStateUpdateStarted,
+	"uninstalling":    AppStateUninstalling,
+	"validating":      AppStateValidating,
+	"downloading":     AppStateDownloading,
+}
+
+// AppStatusResult is the parsed output of the AppStatus command.
+type AppStatusResult struct {
+	// AppID is the ID of the app.
+	AppID int
+	// InstallDir is the directory that the app is installed to. This will be empty if the app is not installed.
+	InstallDir string
+	// BuildID is the build ID of the installed app. This will be 0 if the app is not installed.
+	BuildID int64
+	// BytesDownloaded is the number of bytes that have been downloaded by the current update. This will be 0 if there
+	// is no update in progress.
+	BytesDownloaded int64
+	// BytesTotal is the total number of bytes that will be downloaded by the current update. This will be 0 if there is
+	// no update in progress.
+	BytesTotal int64
+	// StateFlags is the bitmask of the install state of the app. I.e. AppStateFullyInstalled. If the output does not
+	// contain the raw StateFlags, then this is constructed from the names of the install states.
+	StateFlags int
+}
+
+var (
+	// appStatusHeaderPattern matches the header of the output of the AppStatus command. I.e.
+	// "AppID 740 (Counter-Strike Global Offensive - Dedicated Server):".
+	appStatusHeaderPattern = regexp.MustCompile(`AppID (\d+)`)
+	// appStatusBlockPattern matches the header at the start of the output of the AppStatus command for each app, which
+	// is used to split the output of multiple AppStatus commands.
+	appStatusBlockPattern = regexp.MustCompile(`(?m)^AppID (\d+)\b`)
+	// appStatusInstallDirPattern matches the install directory in the output of the AppStatus command.
+	appStatusInstallDirPattern = regexp.MustCompile(`install dir: "([^"]*)"`)
+	// appStatusBuildIDPattern matches the build ID in the output of the AppStatus command.
+	appStatusBuildIDPattern = regexp.MustCompile(`BuildID (\d+)`)
+	// appStatusProgressPattern matches the progress of the current update in the output of the AppStatus command. I.e.
+	// "update state: downloading, progress: 12.34 (123456789 / 999999999)".
+	appStatusProgressPattern = regexp.MustCompile(`progress: \d+(?:\.\d+)? \((\d+) / (\d+)\)`)
+	// appStatusStateFlagsPattern matches the raw StateFlags in the output of the AppStatus command.
+	appStatusStateFlagsPattern = regexp.MustCompile(`StateFlags\s*:?\s*(\d+)`)
+	// appStatusInstallStatePattern matches the comma-separated names of the install states in the output of the
+	// AppStatus command. I.e. "install state: Fully Installed,Update Required,".
+	appStatusInstallStatePattern = regexp.MustCompile(`install state: ([^\r\n]*)`)
+)
+
+// appStatusMaxTries is the maximum number of times that the AppStatus command is sent in interactive mode when its
+// output cannot be validated.
+const appStatusMaxTries = 3
+
+// appStatusOutputFor returns the part of the given output, which can contain the output of multiple AppStatus commands,
+// that belongs to the app with the given ID. This starts at the "AppID ..." header for the app, and ends at the next
+// header. If the output does not contain a header for the app then the output is returned unchanged.
+func appStatusOutputFor(b []byte, appID string) []byte {
+	blocks := appStatusBlockPattern.FindAllSubmatchIndex(b, -1)
+	for i, block := range blocks {
+		if string(b[block[2]:block[3]]) != appID {
+			continue
+		}
+
+		end := len(b)
+		if i < len(blocks)-1 {
+			end = blocks[i+1][0]
+		}
+		return b[block[0]:end]
+	}
+	return b
+}
+
+// parseAppStatus is the CommandOutputParser for the AppStatus command. It parses the output into an *AppStatusResult.
+// Any fields that cannot be found in the output are left as their zero value.
+func parseAppStatus(b []byte) (any, error) {
+	out := string(b)
+	groups := appStatusHeaderPattern.FindStringSubmatch(out)
+	if groups == nil {
+		return nil, errors.New("cannot find the header of the app_status output")
+	}
+
+	var (
+		result AppStatusResult
+		err    error
+	)
+	if result.AppID, err = strconv.Atoi(groups[1]); err != nil {
+		return nil, errors.Wrapf(err, "could not parse app ID %q", groups[1])
+	}
+
+	if groups = appStatusInstallDirPattern.FindStringSubmatch(out); groups != nil {
+		result.InstallDir = groups[1]
+	}
+
+	if groups = appStatusBuildIDPattern.FindStringSubmatch(out); groups != nil {
+		if result.BuildID, err = strconv.ParseInt(groups[1], 10, 64); err != nil {
+			return nil, errors.Wrapf(err, "could not parse build ID %q", groups[1])
+		}
+	}
+
+	if groups = appStatusProgressPattern.FindStringSubmatch(out); groups != nil {
+		if result.BytesDownloaded, err = strconv.ParseInt(groups[1], 10, 64); err != nil {
+			return nil, errors.Wrapf(err, "could not parse bytes downloaded %q", groups[1])
+		}
+		if result.BytesTotal, err = strconv.ParseInt(groups[2], 10, 64); err != nil {
+			return nil, errors.Wrapf(err, "could not parse bytes total %q", groups[2])
+		}
+	}
+
+	if groups = appStatusStateFlagsPattern.FindStringSubmatch(out); groups != nil {
+		if result.StateFlags, err = strconv.Atoi(groups[1]); err != nil {
+			return nil, errors.Wrapf(err, "could not parse StateFlags %q", groups[1])
+		}
+	} else if groups = appStatusInstallStatePattern.FindStringSubmatch(out); groups != nil {
+		for _, state := range splitList(groups[1]) {
+			result.StateFlags |= appStateNames[strings.ToLower(state)]
+		}
+	}
+	return &result, nil
+}
@@ -0,0 +1,110 @@
+package steamcmd
+
+import "testing"
+
+func TestAppStatusParser(t *testing.T) {
+	for testNo, test := range []struct {
+		output      string
+		expected    AppStatusResult
+		expectedErr bool
+	}{
+		{
+			`AppID 740 (Counter-Strike Global Offensive - Dedicated Server):
+ - release state: released (Subscribed,Permanent,)
+ - owner account: 0
+ - install state: Fully Installed,
+ - install dir: "/home/steam/csgo"
+ - mounted depots:
+  731 (5937390394463962917)
+  740 (4513521012456677863)
+ - size on disk: 29853184952 bytes, BuildID 7418361
+`,
+			AppStatusResult{
+				AppID:      740,
+				InstallDir: "/home/steam/csgo",
+				BuildID:    7418361,
+				StateFlags: AppStateFullyInstalled,
+			},
+			false,
+		},
+		{
+			`AppID 740 (Counter-Strike Global Offensive - Dedicated Server):
+ - install state: Fully Installed,Update Required,Update Running,
+ - install dir: "/home/steam/csgo"
+ - size on disk: 29853184952 bytes, BuildID 7418361
+ - update state: downloading, progress: 12.34 (123456789 / 999999999)
+`,
+			AppStatusResult{
+				AppID:           740,
+				InstallDir:      "/home/steam/csgo",
+				BuildID:         7418361,
+				BytesDownloaded: 123456789,
+				BytesTotal:      999999999,
+				StateFlags:      AppStateFullyInstalled | AppStateUpdateRequired | AppStateUpdateRunning,
+			},
+			false,
+		},
+		{
+			"AppID 740:\n - StateFlags : 1026\n - install state: Update Required,Update Started,\n",
+			AppStatusResult{AppID: 740, StateFlags: AppStateUpdateRequired | AppStateUpdateStarted},
+			false,
+		},
+		{
+			"AppID 620 (Portal 2):\n - install state: Uninstalled,\n",
+			AppStatusResult{AppID: 620, StateFlags: AppStateUninstalled},
+			false,
+		},
+		{"No app info for AppID", AppStatusResult{}, true},
+	} {
+		command := NewCommandWithArgs(AppStatus).Command
+		parsedOutput, err := command.Parse([]byte(test.output))
+		if (err != nil) != test.expectedErr {
+			t.Errorf("%d: expected an error: %t, got %v", testNo+1, test.expectedErr, err)
+			continue
+		}
+
+		if test.expectedErr {
+			continue
+		}
+
+		result, ok := parsedOutput.(*AppStatusResult)
+		if !ok {
+			t.Errorf("%d: expected parsed output to be an *AppStatusResult, got %T", testNo+1, parsedOutput)
+			continue
+		}
+
+		if *result != test.expected {
+			t.Errorf("%d: expected %+v, got %+v", testNo+1, test.expected, *result)
+		}
+
+		if !command.ValidateOutput(1, []byte(test.output)) {
+			t.Errorf("%d: expected output to be valid", testNo+1)
+		}
+	}
+}
+
+func TestAppStatusNonInteractive(t *testing.T) {
+	// The fake steamcmd prints the status of each app in the reverse order to how they were queued
+	fakeSteamCMD(t, `printf 'AppID 620 (Portal 2):\n - install state: Uninstalled,\n'
+printf 'AppID 740 (Counter-Strike Global Offensive - Dedicated Server):\n - install state: Fully Installed,\n'`)
+
+	sc := New(false)
+	for _, appID := range []int{740, 620} {
+		if err := sc.AddCommandType(AppStatus, appID); err != nil {
+			t.Fatalf("could not queue AppStatus for %d: %v", appID, err)
+		}
+	}
+
+	if err := sc.Close(); err != nil {
+		t.Fatalf("could not close SteamCMD: %v", err)
+	}
+
+	for testNo, expected := range []AppStatusResult{
+		{AppID: 740, StateFlags: AppStateFullyInstalled},
+		{AppID: 620, StateFlags: AppStateUninstalled},
+	} {
+		if result, ok := sc.ParsedOutputs[testNo].(*AppStatusResult); !ok || *result != expected {
+			t.Errorf("%d: expected %+v, got %+v", testNo+1, expected, sc.ParsedOutputs[testNo])
+		}
+	}
+}
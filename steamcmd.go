@@ -6,48 +6,129 @@
 // that you use CommandType instead as they will be loaded from a binding mapping. A Command or a CommandType is
 // executed immediately only if SteamCMD is in interactive mode.
 //
+// The parsed output of the AppInfoPrint command is a map[string]any. There are helpers to extract commonly used fields
+// from this output, the most useful of which are AppID, AppName, and AppType:
+//
+//	cmd := steamcmd.New(true)
+//	if err := cmd.Flow(steamcmd.NewCommandWithArgs(steamcmd.AppInfoPrint, 477160)); err != nil {
+//		panic(err)
+//	}
+//	output := cmd.ParsedOutputs[0].(map[string]any)
+//	appID, _ := steamcmd.AppID(output)     // 477160
+//	name, _ := steamcmd.AppName(output)    // "Human: Fall Flat"
+//	appType, _ := steamcmd.AppType(output) // "Game"
+//
 // One final thing to note is that you need the "steamcmd" binary installed on your path for the SteamCMD wrapper to
 // work.
 package steamcmd
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"github.com/Netflix/go-expect"
 	"github.com/andygello555/agem"
 	"github.com/pkg/errors"
 	"io"
 	"os/exec"
+	"regexp"
 	"strings"
+	"sync"
 	"time"
 )
 
 const (
-	// InteractivePrompt is the prompt that SteamCMD uses in interactive mode.
+	// InteractivePrompt is the prompt that SteamCMD uses in interactive mode. This is matched exactly unless
+	// WithPromptRegex is given.
 	InteractivePrompt = "Steam>"
 	// ExpectTimeout is the timeout for the Expect calls.
 	ExpectTimeout = time.Minute
-	// WaitTimeout is the amount of time to wait for the process to shut down.
+	// AppUpdateTimeout is the Command.Timeout of the AppUpdate command, as downloading an app can take a lot longer than
+	// ExpectTimeout.
+	AppUpdateTimeout = time.Hour * 6
+	// WaitTimeout is the default amount of time to wait for the process to shut down. This can be overridden for a
+	// SteamCMD by using SteamCMD.SetWaitTimeout, or for a single close by using SteamCMD.CloseWithTimeout.
 	WaitTimeout = time.Second * 5
+	// DefaultMaxParseSize is the default maximum size in bytes of the output of an AppInfoPrint command that will be
+	// parsed. This can be overridden using WithMaxParseSize.
+	DefaultMaxParseSize = 10 * 1024 * 1024
 )
 
+// DefaultPromptRegex is a convenience regexp.Regexp that can be given to WithPromptRegex. It will match the
+// InteractivePrompt regardless of any surrounding whitespace or ANSI colour codes.
+var DefaultPromptRegex = regexp.MustCompile(`Steam>`)
+
+// ErrQuitMustBeLast is returned when a Command is queued/executed after the Quit command.
+var ErrQuitMustBeLast = errors.New("Quit must be the last command")
+
+// ErrInstallDirAfterAppUpdate is returned when the ForceInstallDir command is queued/executed after an AppUpdate
+// command, as the AppUpdate command would have already installed the app to the previous directory.
+var ErrInstallDirAfterAppUpdate = errors.New("ForceInstallDir must come before any AppUpdate command")
+
+// ErrUninstallRequiresLogin is returned when the AppUninstall command is queued/executed by a SteamCMD that has not
+// logged in with an account. The SteamCMD must either be created using NewWithCredentials, or have the Login command
+// queued/executed before the AppUninstall command.
+var ErrUninstallRequiresLogin = errors.New("AppUninstall requires a login with an account")
+
+// ErrNonInteractiveTimeout is returned by SteamCMD.Close when a non-interactive SteamCMD process does not exit within
+// the timeout given to WithNonInteractiveTimeout. The process is killed, and the stdout that was captured before it
+// was killed is stored in Output.
+type ErrNonInteractiveTimeout struct {
+	Timeout time.Duration
+	Output  []byte
+}
+
+func (e ErrNonInteractiveTimeout) Error() string {
+	return fmt.Sprintf("non-interactive SteamCMD did not exit within %s", e.Timeout.String())
+}
+
+// ErrOutputTooLarge is returned when the output of an AppInfoPrint command is larger than the limit set by
+// WithMaxParseSize. This usually indicates that the output was not trimmed correctly.
+type ErrOutputTooLarge struct {
+	Size  int
+	Limit int
+}
+
+func (e ErrOutputTooLarge) Error() string {
+	return fmt.Sprintf("output of %d bytes is larger than the limit of %d bytes", e.Size, e.Limit)
+}
+
+// ErrFlowAborted is returned by SteamCMD.Flow when SteamCMD.AbortFlow has been called.
+type ErrFlowAborted struct {
+	Reason string
+}
+
+func (e ErrFlowAborted) Error() string {
+	return fmt.Sprintf("flow aborted: %s", e.Reason)
+}
+
 // SteamCMD is a wrapper for the Steam CLI client (steamcmd). It can run a sequence of Command in both interactive and
 // non-interactive modes.
 type SteamCMD struct {
 	// commands is a list of Command that are queued up.
 	commands []*Command
+	// commandArgs is a list of the args that each Command within commands was queued up with. It mirrors commands, so
+	// the args for commands[i] are commandArgs[i].
+	commandArgs [][]any
 	// stdout is an additional io.Writer to write the stdout of the cmd to. This can be set in NewDebug, but it will be
 	// defaulted to io.Discard in the New constructor.
 	stdout io.Writer
 	// stderr is an additional io.Writer to write the stderr of the cmd to. This can be set in NewDebug, but it will be
 	// defaulted to io.Discard in the New constructor.
 	stderr io.Writer
-	// serialisedCommands is a list of serialised Command (with their args).
+	// directives is a list of SteamCMD directives (i.e. "+@ShutdownOnFailedCommand 1") that will be passed to the
+	// steamcmd binary before any of the serialisedCommands.
+	directives []string
+	// serialisedCommands is a list of serialised Command (with their args). The first serialised command is always the
+	// login command, which is anonymous unless the SteamCMD was created using NewWithCredentials.
 	serialisedCommands []string
 	// interactive indicates whether the SteamCMD was started in interactive mode.
 	interactive bool
 	// console is the expect.Console that is used for expecting prompts from SteamCMD when it's running in interactive
 	// mode.
 	console *expect.Console
+	// consoleMu is used to make sure that only one Command or heartbeat is being sent to the console at a time.
+	consoleMu sync.Mutex
 	// cmd is the exec.Cmd that is used to manage the SteamCMD process.
 	cmd *exec.Cmd
 	// before is the buffer of bytes that represent the output of the current Command. This is only used in interactive
@@ -60,25 +141,121 @@ type SteamCMD struct {
 	closed bool
 	// quitYet is set when the Quit command is first queued/executed.
 	quitYet bool
+	// loggedIn is set when the SteamCMD is created using NewWithCredentials, or when the Login command is
+	// queued/executed. It is used to guard the AppUninstall command.
+	loggedIn bool
+	// traces contains an ExecutionTrace for each queued/executed Command.
+	traces []ExecutionTrace
+	// waitTimeout is the amount of time to wait for the process to shut down when calling SteamCMD.Close. This is
+	// defaulted to WaitTimeout.
+	waitTimeout time.Duration
+	// nonInteractiveTimeout is the timeout set by WithNonInteractiveTimeout. If this is 0 then a non-interactive
+	// SteamCMD process can run forever.
+	nonInteractiveTimeout time.Duration
+	// maxParseSize is the maximum size of the output of an AppInfoPrint command that will be parsed. This is defaulted to
+	// DefaultMaxParseSize, and can be set using WithMaxParseSize.
+	maxParseSize int
+	// numericCoercion is set by WithNumericCoercion.
+	numericCoercion bool
+	// freshData is set by WithFreshData.
+	freshData bool
+	// updateRanThisSession is set when the AppInfoUpdate command is queued/executed automatically because freshData is
+	// set, so that it is only run once per session.
+	updateRanThisSession bool
+	// commandTimeouts are the timeouts set by WithCommandTimeout for each CommandType.
+	commandTimeouts map[CommandType]time.Duration
+	// promptRegex is the regexp.Regexp set by WithPromptRegex that is used to match the prompt.
+	promptRegex *regexp.Regexp
+	// redactPatterns are the patterns set by WithRedactSecrets.
+	redactPatterns []*regexp.Regexp
+	// lineCallback is the callback set by WithLineCallback.
+	lineCallback func(line string)
+	// redactWriters are the redactWriter(s) returned by outputWriters that need to be flushed once the process exits.
+	redactWriters []*redactWriter
+	// subscribers are the channels returned by SteamCMD.Subscribe.
+	subscribers []*subscriber
+	// subscribersMu protects subscribers.
+	subscribersMu sync.Mutex
+	// eventBufferSize is the buffer size of the channels returned by SteamCMD.Subscribe.
+	eventBufferSize int
+	// heartbeatInterval is the interval between heartbeats set by WithHeartbeat. If this is 0 then no heartbeat is sent.
+	heartbeatInterval time.Duration
+	// heartbeatTimeout is the timeout set by WithHeartbeatTimeout. If this is 0 then ExpectTimeout is used.
+	heartbeatTimeout time.Duration
+	// heartbeatCancel will stop the heartbeat goroutine.
+	heartbeatCancel context.CancelFunc
+	// heartbeatWg is used to wait for the heartbeat goroutine to stop.
+	heartbeatWg sync.WaitGroup
+	// heartbeatErr is the error that caused the heartbeat goroutine to stop, if any.
+	heartbeatErr error
+	// abortRequested is set by SteamCMD.AbortFlow.
+	abortRequested bool
+	// abortReason is the reason given to SteamCMD.AbortFlow.
+	abortReason string
+	// abortMu protects abortRequested and abortReason, as SteamCMD.AbortFlow can be called from another goroutine. It
+	// also protects writes to closed, as closed is read by SteamCMD.AbortFlow.
+	abortMu sync.Mutex
+	// recorder is the RecordedSession set by NewRecorder.
+	recorder *RecordedSession
+	// replay are the RecordedCommand that are yet to be replayed by a SteamCMD created using NewReplaySession. If this is
+	// not nil, then the steamcmd binary is never started.
+	replay []RecordedCommand
 	// ParsedOutputs is the list of parsed outputs from Command.Parse from each queued/executed Command. This means that
 	// the output of the third command will lie at index 2.
 	ParsedOutputs []any
 }
 
-// New creates a new SteamCMD. You can specify whether to run Command in interactive mode or not.
-func New(interactive bool) *SteamCMD {
-	return NewDebug(interactive, io.Discard, io.Discard)
+// New creates a new SteamCMD. You can specify whether to run Command in interactive mode or not, as well as any Option
+// to configure the SteamCMD with.
+func New(interactive bool, opts ...Option) *SteamCMD {
+	return NewDebug(interactive, io.Discard, io.Discard, opts...)
 }
 
-func NewDebug(interactive bool, stdout, stderr io.Writer) *SteamCMD {
-	return &SteamCMD{
+// NewDebug creates a new SteamCMD in the same way as New, but the stdout and stderr of the steamcmd process will also be
+// written to the given io.Writer(s).
+func NewDebug(interactive bool, stdout, stderr io.Writer, opts ...Option) *SteamCMD {
+	sc := &SteamCMD{
 		commands:           make([]*Command, 0),
 		stdout:             stdout,
 		stderr:             stderr,
 		serialisedCommands: []string{"+login anonymous"},
 		interactive:        interactive,
+		traces:             make([]ExecutionTrace, 0),
+		waitTimeout:        WaitTimeout,
+		eventBufferSize:    DefaultEventBufferSize,
+		maxParseSize:       DefaultMaxParseSize,
 		ParsedOutputs:      make([]any, 0),
 	}
+
+	for _, opt := range opts {
+		opt(sc)
+	}
+	return sc
+}
+
+// NewWithCredentials creates a new SteamCMD in the same way as New, but the SteamCMD will log in with the given username
+// and password instead of anonymously. The Steam Guard code can be left empty if the account does not require one.
+// The login is serialised using the Login command, but is not added to the queued commands, so the credentials will not
+// appear in any ExecutionTrace or RecordedSession. The password and Steam Guard code are also redacted from any errors
+// that include the login. However, they will still appear in the output of the steamcmd process, so WithRedactSecrets
+// should be given when using NewDebug or WithLineCallback.
+func NewWithCredentials(username, password, steamGuard string, interactive bool, opts ...Option) *SteamCMD {
+	sc := New(interactive, opts...)
+	login := commands[Login]
+	sc.serialisedCommands[0] = login.Serialise(username, password, steamGuard)
+	sc.loggedIn = true
+	return sc
+}
+
+// SetWaitTimeout sets the amount of time that SteamCMD.Close will wait for the SteamCMD process to shut down before it
+// is killed.
+func (sc *SteamCMD) SetWaitTimeout(timeout time.Duration) {
+	sc.waitTimeout = timeout
+}
+
+// commandLine returns the arguments that will be passed to the steamcmd binary.
+func (sc *SteamCMD) commandLine() []string {
+	return append(append([]string{}, sc.directives...), sc.serialisedCommands...)
 }
 
 // setBuffers is called by expectString, and expectEOF to update the after, before, and interactiveBuffer buffers.
@@ -90,45 +267,127 @@ func (sc *SteamCMD) setBuffers(serialisedCommand string, read string, expected s
 	sc.after.WriteString(expected)
 }
 
+// expectPrompt returns the expect.ExpectOpt that matches the prompt. This will be the regexp.Regexp given to
+// WithPromptRegex, or the InteractivePrompt if no regexp.Regexp was given.
+func (sc *SteamCMD) expectPrompt() expect.ExpectOpt {
+	if sc.promptRegex != nil {
+		return expect.Regexp(sc.promptRegex)
+	}
+	return expect.String(InteractivePrompt)
+}
+
 // expectString will call ExpectString on the console with the given string. It will then set the after buffer to be the
 // string read by ExpectString, and the before buffer to be the output that was read from the previous expectString up
 // until this one. interactiveBuffer will also be reset to accommodate the next call to expectString.
-func (sc *SteamCMD) expectString(serialisedCommand string, s string) error {
-	msg, err := sc.console.Expect(expect.String(s), expect.WithTimeout(ExpectTimeout))
+//
+// If WithPromptRegex was given and the given string is the InteractivePrompt, then the regexp.Regexp will be used
+// instead, and the after buffer will be set to the last match of the regexp.Regexp.
+func (sc *SteamCMD) expectString(serialisedCommand string, s string, timeout time.Duration) error {
+	opt := expect.String(s)
+	if s == InteractivePrompt {
+		opt = sc.expectPrompt()
+	}
+
+	msg, err := sc.console.Expect(opt, expect.WithTimeout(timeout))
 	if err != nil {
 		return errors.Wrapf(err, "error whilst expecting \"%s\" from interactive SteamCMD", s)
 	}
+
+	if s == InteractivePrompt && sc.promptRegex != nil {
+		if matches := sc.promptRegex.FindAllString(msg, -1); len(matches) > 0 {
+			s = matches[len(matches)-1]
+		}
+	}
 	sc.setBuffers(serialisedCommand, msg, s)
 	return nil
 }
 
-// closeInteractive will clean up the cmd and console that are used to manage the interactive mode.
-func (sc *SteamCMD) closeInteractive() (err error) {
-	if sc.cmd != nil {
+// commandTimeout returns the amount of time to wait for the prompt after the given Command. This is the timeout given
+// to WithCommandTimeout for the Command's CommandType, the Command.Timeout, or ExpectTimeout, in that order.
+func (sc *SteamCMD) commandTimeout(command *Command) time.Duration {
+	if timeout, ok := sc.commandTimeouts[ResolveAlias(command.Type)]; ok {
+		return timeout
+	}
+
+	if command.Timeout > 0 {
+		return command.Timeout
+	}
+	return ExpectTimeout
+}
+
+// expectPromptOrPattern will expect either the prompt or the given regexp.Regexp from the console, and set the after
+// and before buffers in the same way as expectString. This is used after Command that can cause steamcmd to wait for
+// input without printing the prompt.
+func (sc *SteamCMD) expectPromptOrPattern(serialisedCommand string, pattern *regexp.Regexp, timeout time.Duration) error {
+	msg, err := sc.console.Expect(sc.expectPrompt(), expect.Regexp(pattern), expect.WithTimeout(timeout))
+	if err != nil {
+		return errors.Wrapf(err, "error whilst expecting the prompt or %q from interactive SteamCMD", pattern)
+	}
+
+	expected := InteractivePrompt
+	promptRegex := sc.promptRegex
+	if promptRegex == nil {
+		promptRegex = regexp.MustCompile(regexp.QuoteMeta(InteractivePrompt))
+	}
+
+	// Expect returns as soon as either matches, so whichever match ends the output is the one that was expected
+	for _, re := range []*regexp.Regexp{promptRegex, pattern} {
+		if matches := re.FindAllString(msg, -1); len(matches) > 0 && strings.HasSuffix(msg, matches[len(matches)-1]) {
+			expected = matches[len(matches)-1]
+		}
+	}
+	sc.setBuffers(serialisedCommand, msg, expected)
+	return nil
+}
+
+// closeInteractive will clean up the cmd and console that are used to manage the interactive mode. The given timeout
+// is the amount of time to wait for the process to shut down before it is killed. The process will also be killed if
+// the given context.Context is done before the process shuts down.
+func (sc *SteamCMD) closeInteractive(ctx context.Context, timeout time.Duration) (err error) {
+	// The heartbeat needs to be stopped before we quit, so that it doesn't try to reconnect
+	err = sc.stopHeartbeat()
+
+	if sc.cmd != nil && sc.cmd.Process != nil {
 		// We only add the Quit command if quitYet is not set
 		if !sc.quitYet {
-			err = sc.AddCommandType(Quit)
+			err = agem.MergeErrors(err, sc.AddCommandType(Quit))
 		}
 
+		// We use Cmd.Wait rather than Process.Wait so that we also wait for the goroutines that copy the process' output
+		// to the redactWriter(s). Otherwise, these might still be writing whilst the redactWriter(s) are flushed.
 		waitErrChan := make(chan error)
 		go func() {
-			_, waitErr := sc.cmd.Process.Wait()
+			waitErr := sc.cmd.Wait()
+			// A non-zero exit code is reported using the ProcessState below
+			var exitErr *exec.ExitError
+			if errors.As(waitErr, &exitErr) {
+				waitErr = nil
+			}
 			waitErrChan <- waitErr
 		}()
 
 		var waitErr error
 		select {
-		case <-time.After(WaitTimeout):
+		case <-time.After(timeout):
 			// If the initial wait times out then we will kill the process. The goroutine that was started above should
 			// then wait until the process' resources are cleared.
 			err = agem.MergeErrors(err, errors.Wrap(sc.cmd.Process.Kill(), "process kill failed"))
 			waitErr = <-waitErrChan
+		case <-ctx.Done():
+			err = agem.MergeErrors(err, ctx.Err(), errors.Wrap(sc.cmd.Process.Kill(), "process kill failed"))
+			waitErr = <-waitErrChan
 		case waitErr = <-waitErrChan:
 			break
 		}
 		err = agem.MergeErrors(err, errors.Wrap(waitErr, "wait failed"))
-		sc.cmd = nil
+
+		// The exit code will be -1 if the process was killed
+		if state := sc.cmd.ProcessState; state != nil && state.ExitCode() > 0 {
+			err = agem.MergeErrors(ExitCodeToError(state.ExitCode()), err)
+		}
 	}
+	sc.cmd = nil
+	err = agem.MergeErrors(err, sc.flushOutputWriters())
 
 	if sc.console != nil {
 		err = agem.MergeErrors(err, sc.console.Close())
@@ -141,28 +400,67 @@ func (sc *SteamCMD) closeInteractive() (err error) {
 	return
 }
 
-// startInteractive mode will set the console and cmd fields that are used to manage the interactive mode.
-func (sc *SteamCMD) startInteractive() (err error) {
+// parseOutput will parse the given output using Command.Parse. If the Command is AppInfoPrint and the output is larger
+// than the limit set by WithMaxParseSize, then ErrOutputTooLarge is returned without parsing the output. If
+// WithNumericCoercion was given, then the parsed output of AppInfoPrint will be passed through CoerceNumericStrings.
+func (sc *SteamCMD) parseOutput(command *Command, out []byte) (any, error) {
+	if command.Type == AppInfoPrint && sc.maxParseSize > 0 && len(out) > sc.maxParseSize {
+		return nil, ErrOutputTooLarge{Size: len(out), Limit: sc.maxParseSize}
+	}
+
+	parsedOutput, err := command.Parse(out)
+	if output, ok := parsedOutput.(map[string]any); ok && err == nil && command.Type == AppInfoPrint {
+		// Only the first object is parsed, so we warn any subscribers that the other objects have been ignored
+		if objects := len(appInfoObjectHeaderPattern.FindAllIndex(out, -1)); objects > 1 {
+			sc.publish(EventParseWarning, ErrMultipleAppInfoObjects{Objects: objects})
+		}
+
+		if sc.numericCoercion {
+			parsedOutput = CoerceNumericStrings(output)
+		}
+	}
+	return parsedOutput, err
+}
+
+// startProcess will start the steamcmd binary with the given arguments, using a new console for its stdin and stdout.
+// It will then wait for the InteractivePrompt.
+func (sc *SteamCMD) startProcess(args []string) (err error) {
 	if sc.console, err = expect.NewConsole(); err != nil {
 		return errors.Wrap(err, "could not start SteamCMD in interactive mode")
 	}
+
+	stdout, stderr := sc.outputWriters()
+	sc.cmd = exec.Command("steamcmd", args...)
+	sc.cmd.Stdin = sc.console.Tty()
+	sc.cmd.Stdout = io.MultiWriter(sc.console.Tty(), stdout)
+	sc.cmd.Stderr = io.MultiWriter(sc.console.Tty(), stderr)
+	if err = sc.cmd.Start(); err != nil {
+		return errors.Wrap(err, "could not start SteamCMD binary")
+	}
+
+	if err = sc.expectString("", InteractivePrompt, ExpectTimeout); err != nil {
+		return errors.Wrap(err, "error occurred whilst expecting prompt for SteamCMD")
+	}
+	return
+}
+
+// startInteractive mode will set the console and cmd fields that are used to manage the interactive mode. If
+// WithHeartbeat was given, then the heartbeat will also be started.
+func (sc *SteamCMD) startInteractive() (err error) {
 	defer func() {
 		if err != nil {
 			// If an error has occurred whilst starting interactive mode we will close the SteamCMD
-			err = agem.MergeErrors(err, sc.closeInteractive())
+			err = agem.MergeErrors(err, sc.closeInteractive(context.Background(), sc.waitTimeout))
 		}
 	}()
 
-	sc.cmd = exec.Command("steamcmd", sc.serialisedCommands...)
-	sc.cmd.Stdin = sc.console.Tty()
-	sc.cmd.Stdout = io.MultiWriter(sc.console.Tty(), sc.stdout)
-	sc.cmd.Stderr = io.MultiWriter(sc.console.Tty(), sc.stderr)
-	if err = sc.cmd.Start(); err != nil {
-		return errors.Wrap(err, "could not start SteamCMD binary")
+	if err = sc.startProcess(sc.commandLine()); err != nil {
+		return
 	}
+	sc.publish(EventSessionStarted, nil)
 
-	if err = sc.expectString("", InteractivePrompt); err != nil {
-		return errors.Wrap(err, "error occurred whilst expecting prompt for SteamCMD")
+	if sc.heartbeatInterval > 0 {
+		sc.startHeartbeat()
 	}
 	return
 }
@@ -170,21 +468,47 @@ func (sc *SteamCMD) startInteractive() (err error) {
 // executeInteractive will execute the given Command immediately when SteamCMD is in interactive mode. The Command will
 // be retried until Command.ValidateOutput succeeds.
 func (sc *SteamCMD) executeInteractive(command *Command, args ...any) (err error) {
+	sc.consoleMu.Lock()
+	defer sc.consoleMu.Unlock()
+
 	// Reset the buffers, so we don't get any leaks from the previous command
 	sc.before.Reset()
 	sc.after.Reset()
 	serialisedCommand := command.Serialise(args...)[1:]
+	redactedCommand := sc.redactSecrets(command.SerialiseRedacted(args...)[1:])
+	timeout := sc.commandTimeout(command)
 
 	// We keep executing the command until we can validate the output
 	tryNo := 0
+	trace := ExecutionTrace{CommandType: command.Type, Args: command.redactArgs(args)}
+	startTime := time.Now()
+	defer func() {
+		trace.setTimes(startTime, time.Now())
+		trace.Err = err
+		if tryNo > 0 {
+			trace.Retries = tryNo - 1
+		}
+		sc.traces = append(sc.traces, trace)
+		sc.publish(EventCommandExecuted, trace)
+	}()
+
 	for !command.ValidateOutput(tryNo, sc.before.Bytes()) {
 		//fmt.Printf("Sending line: \"%s\"\n", serialisedCommand)
 		if _, err = sc.console.SendLine(serialisedCommand); err != nil {
-			return errors.Wrapf(err, "could not send command \"%s\" to the interactive SteamCMD", serialisedCommand)
+			return errors.Wrapf(
+				err, "could not send command \"%s\" to the interactive SteamCMD", redactedCommand,
+			)
 		}
 
-		if command.Type != Quit {
-			if err = sc.expectString(serialisedCommand, InteractivePrompt); err != nil {
+		switch command.Type {
+		case Quit:
+		case Login:
+			// steamcmd waits on a Steam Guard code prompt, rather than the InteractivePrompt, when one is required
+			if err = sc.expectPromptOrPattern(serialisedCommand, loginSteamGuardPromptPattern, timeout); err != nil {
+				return errors.Wrapf(err, "could not expect SteamCMD prompt after %s command", command.Type.String())
+			}
+		default:
+			if err = sc.expectString(serialisedCommand, InteractivePrompt, timeout); err != nil {
 				return errors.Wrapf(err, "could not expect SteamCMD prompt after %s command", command.Type.String())
 			}
 		}
@@ -194,10 +518,13 @@ func (sc *SteamCMD) executeInteractive(command *Command, args ...any) (err error
 	}
 
 	var parsedOutput any
-	if parsedOutput, err = command.Parse(sc.before.Bytes()); err != nil {
-		err = errors.Wrapf(err, "could not parse output for command \"%s\"", serialisedCommand)
+	if parsedOutput, err = sc.parseOutput(command, sc.before.Bytes()); err != nil {
+		err = errors.Wrapf(err, "could not parse output for command \"%s\"", redactedCommand)
 	}
 	sc.ParsedOutputs = append(sc.ParsedOutputs, parsedOutput)
+	if sc.recorder != nil {
+		sc.recorder.record(command, command.redactArgs(args), sc.before.Bytes(), parsedOutput)
+	}
 	return
 }
 
@@ -211,18 +538,53 @@ func (sc *SteamCMD) AddCommand(command *Command, args ...any) (err error) {
 
 	// If we have already quit then we cannot execute any more commands
 	if sc.quitYet {
-		return errors.New("cannot queue/execute more commands after queuing/executing Quit command")
+		return errors.Wrap(ErrQuitMustBeLast, "cannot queue/execute more commands after queuing/executing Quit command")
 	}
 
 	if !command.ValidateArgs(args...) {
-		err = errors.Errorf("command \"%s\" was given an invalid arg (%v)", command.Type.String(), args)
+		err = errors.Errorf(
+			"command \"%s\" was given an invalid arg (%v)", command.Type.String(), command.redactArgs(args),
+		)
 		return
 	}
 
+	if command.Type == ForceInstallDir && sc.hasQueued(AppUpdate) {
+		return errors.Wrap(ErrInstallDirAfterAppUpdate, "cannot queue/execute ForceInstallDir command")
+	}
+
+	// Uninstalling with an anonymous login could remove apps that belong to another account
+	if command.Type == AppUninstall && !sc.loggedIn {
+		return errors.Wrapf(
+			ErrUninstallRequiresLogin, "cannot queue/execute command \"%s\" without logging in",
+			command.Serialise(args...),
+		)
+	}
+
+	// In interactive mode, we only consider the SteamCMD logged in once the Login command has succeeded. Logging in
+	// anonymously does not count, as we would then be able to uninstall apps that belong to another account.
+	if command.Type == Login {
+		defer func() {
+			if err == nil {
+				username, _ := args[0].(string)
+				sc.loggedIn = !isAnonymousLogin(username)
+			}
+		}()
+	}
+
+	// If WithFreshData was given, then we update the app info cache once before the first AppInfoPrint command
+	if sc.freshData && !sc.updateRanThisSession && command.Type == AppInfoPrint {
+		sc.updateRanThisSession = true
+		if err = sc.AddCommandType(AppInfoUpdate, 1); err != nil {
+			return errors.Wrap(err, "could not update app info before the first AppInfoPrint command")
+		}
+	}
+
 	// Add the serialised command and the regular command
 	//fmt.Printf("Queuing/executing command \"%s\"\n", command.Serialise(args...))
 	sc.commands = append(sc.commands, command)
+	sc.commandArgs = append(sc.commandArgs, args)
 	sc.serialisedCommands = append(sc.serialisedCommands, command.Serialise(args...))
+	sc.publish(EventCommandQueued, &CommandWithArgs{Command: command, Args: args})
 
 	// Check if the command's type is Quit and set the quitYet flag accordingly
 	if command.Type == Quit {
@@ -232,17 +594,70 @@ func (sc *SteamCMD) AddCommand(command *Command, args ...any) (err error) {
 		sc.quitYet = true
 	}
 
-	// If SteamCMD is interactive, then we will execute the command straight away
+	// If SteamCMD is interactive, then we will execute the command straight away. Otherwise, we add an ExecutionTrace for
+	// the command which will have its times set once the non-interactive SteamCMD has been run.
 	if sc.interactive {
+		if sc.replay != nil {
+			return sc.executeReplay(command, args...)
+		}
 		return sc.executeInteractive(command, args...)
 	}
+	sc.traces = append(sc.traces, ExecutionTrace{CommandType: command.Type, Args: command.redactArgs(args)})
 	return
 }
 
+// hasQueued checks whether a Command of the given CommandType has been queued/executed.
+func (sc *SteamCMD) hasQueued(commandType CommandType) bool {
+	for _, command := range sc.commands {
+		if command.Type == commandType {
+			return true
+		}
+	}
+	return false
+}
+
+// ExecuteRaw will send the given raw command to the SteamCMD process, then wait for either the waitFor string or the
+// InteractivePrompt (whichever comes first) for up to the given timeout. The output that was read up to and including
+// the matched string is returned. If waitFor is empty, then only the InteractivePrompt will be waited for.
+//
+// WARNING: this is an unsafe escape hatch for executing experimental or undocumented steamcmd commands that do not
+// have a Command. It bypasses all validation and parsing, and the command will not be added to the queued commands,
+// ParsedOutputs, or traces. It can only be used when SteamCMD is in interactive mode and the process has been started.
+func (sc *SteamCMD) ExecuteRaw(command string, waitFor string, timeout time.Duration) (out []byte, err error) {
+	if !sc.interactive {
+		return nil, errors.New("cannot execute a raw command when SteamCMD is not in interactive mode")
+	}
+
+	sc.consoleMu.Lock()
+	defer sc.consoleMu.Unlock()
+	if sc.cmd == nil || sc.console == nil {
+		return nil, errors.New("cannot execute a raw command when the SteamCMD process is not running")
+	}
+
+	if _, err = sc.console.SendLine(command); err != nil {
+		return nil, errors.Wrapf(
+			err, "could not send raw command \"%s\" to the interactive SteamCMD", sc.redactSecrets(command),
+		)
+	}
+
+	opts := []expect.ExpectOpt{sc.expectPrompt(), expect.WithTimeout(timeout)}
+	if waitFor != "" {
+		opts = append(opts, expect.String(waitFor))
+	}
+
+	var msg string
+	if msg, err = sc.console.Expect(opts...); err != nil {
+		err = errors.Wrapf(
+			err, "error whilst expecting %q or the prompt after raw command \"%s\"", waitFor, sc.redactSecrets(command),
+		)
+	}
+	return []byte(msg), err
+}
+
 // AddCommandType will look up the given CommandType in the default command lookup, then add that command using
 // AddCommand.
 func (sc *SteamCMD) AddCommandType(commandType CommandType, args ...any) (err error) {
-	if command, ok := commands[commandType]; ok {
+	if command, ok := lookupCommand(commandType); ok {
 		return sc.AddCommand(&command, args...)
 	} else {
 		err = errors.Errorf(
@@ -259,25 +674,51 @@ func (sc *SteamCMD) Start() (err error) {
 		if sc.closed {
 			return errors.New("cannot start a SteamCMD that is closed")
 		}
+		// A SteamCMD that is replaying a recording never starts the steamcmd binary
+		if sc.replay != nil {
+			sc.publish(EventSessionStarted, nil)
+			return
+		}
 		return sc.startInteractive()
 	}
 	return
 }
 
 // Close will stop the SteamCMD process, if it is in interactive mode. Otherwise, the command will be executed all at
-// once.
+// once. The timeout set by SteamCMD.SetWaitTimeout (WaitTimeout by default) is used to wait for the process to shut
+// down.
 func (sc *SteamCMD) Close() (err error) {
+	return sc.CloseContext(context.Background())
+}
+
+// CloseWithTimeout is the same as SteamCMD.Close, but the given timeout will override the amount of time to wait for
+// the SteamCMD process to shut down for this call only. The timeout is only used when SteamCMD is in interactive mode.
+func (sc *SteamCMD) CloseWithTimeout(timeout time.Duration) (err error) {
+	return sc.close(context.Background(), timeout)
+}
+
+// CloseContext is the same as SteamCMD.Close, but the SteamCMD process will be killed if the given context.Context is
+// done before the process exits. In non-interactive mode, this is also combined with the timeout given to
+// WithNonInteractiveTimeout.
+func (sc *SteamCMD) CloseContext(ctx context.Context) (err error) {
+	return sc.close(ctx, sc.waitTimeout)
+}
+
+// close is the implementation for SteamCMD.Close, SteamCMD.CloseWithTimeout, and SteamCMD.CloseContext.
+func (sc *SteamCMD) close(ctx context.Context, timeout time.Duration) (err error) {
 	if !sc.closed {
 		// Only set closed when we have closed the SteamCMD without errors
 		defer func() {
 			if err == nil {
-				sc.closed = true
+				sc.setClosed()
+				sc.publish(EventSessionClosed, nil)
+				sc.unsubscribeAll()
 			}
 		}()
 
 		// If SteamCMD is interactive, we delegate closing to closeInteractive
 		if sc.interactive {
-			return sc.closeInteractive()
+			return sc.closeInteractive(ctx, timeout)
 		}
 
 		// We add a quit command if the user hasn't yet
@@ -287,21 +728,64 @@ func (sc *SteamCMD) Close() (err error) {
 			}
 		}
 
+		// Derive the deadline for the process from the timeout given to WithNonInteractiveTimeout
+		runCtx := ctx
+		if sc.nonInteractiveTimeout > 0 {
+			var cancel context.CancelFunc
+			runCtx, cancel = context.WithTimeout(ctx, sc.nonInteractiveTimeout)
+			defer cancel()
+		}
+
 		// Execute the non-interactive command all at once
 		var stdout bytes.Buffer
-		sc.cmd = exec.Command("steamcmd", sc.serialisedCommands...)
-		sc.cmd.Stdout = &stdout
-		if err = sc.cmd.Run(); err != nil {
-			return errors.Wrapf(err, "could not run non-interactive series of commands for SteamCMD (%v)", sc.serialisedCommands)
+		stdoutWriter, stderrWriter := sc.outputWriters()
+		sc.cmd = exec.CommandContext(runCtx, "steamcmd", sc.commandLine()...)
+		sc.cmd.Stdout = io.MultiWriter(&stdout, stdoutWriter)
+		sc.cmd.Stderr = stderrWriter
+		startTime := time.Now()
+		sc.publish(EventSessionStarted, nil)
+		err = sc.cmd.Run()
+		endTime := time.Now()
+		err = agem.MergeErrors(err, sc.flushOutputWriters())
+		for i := range sc.traces {
+			sc.traces[i].setTimes(startTime, endTime)
+		}
+
+		if err != nil {
+			// Only the timeout given to WithNonInteractiveTimeout results in ErrNonInteractiveTimeout. If the parent
+			// context is done then we return its error instead.
+			if ctx.Err() == nil && errors.Is(runCtx.Err(), context.DeadlineExceeded) {
+				return ErrNonInteractiveTimeout{Timeout: sc.nonInteractiveTimeout, Output: stdout.Bytes()}
+			}
+
+			// The typed error for the exit code is placed first so that it can be checked using errors.Is
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+				err = agem.MergeErrors(ExitCodeToError(exitErr.ExitCode()), err)
+			}
+			return errors.Wrapf(
+				agem.MergeErrors(err, ctx.Err()),
+				"could not run non-interactive series of commands for SteamCMD (%v)", sc.serialisedCommandsRedacted(),
+			)
+		}
+
+		for _, trace := range sc.traces {
+			sc.publish(EventCommandExecuted, trace)
 		}
 
 		// Parse the output for each command
 		for i, command := range sc.commands {
+			out := nonInteractiveOutput(command, sc.commandArgs[i], stdout.Bytes())
 			var parsedOutput any
-			if parsedOutput, err = command.Parse(stdout.Bytes()); err != nil {
-				return errors.Wrapf(err, "could not parse output for command \"%s\"", sc.serialisedCommands[i])
+			if parsedOutput, err = sc.parseOutput(command, out); err != nil {
+				return errors.Wrapf(
+					err, "could not parse output for command \"%s\"", sc.serialisedCommandRedacted(i+1),
+				)
 			}
 			sc.ParsedOutputs = append(sc.ParsedOutputs, parsedOutput)
+			if sc.recorder != nil {
+				sc.recorder.record(command, command.redactArgs(sc.commandArgs[i]), out, parsedOutput)
+			}
 		}
 		return
 	} else {
@@ -309,6 +793,23 @@ func (sc *SteamCMD) Close() (err error) {
 	}
 }
 
+// nonInteractiveOutput returns the part of the given stdout of a non-interactive SteamCMD that belongs to the given
+// Command. As the output of each Command cannot be separated in non-interactive mode, this is only possible for Command
+// whose output can be identified by its args. For all other Command, the entire stdout is returned.
+func nonInteractiveOutput(command *Command, args []any, stdout []byte) []byte {
+	switch command.Type {
+	case AppInfoPrint:
+		if len(args) > 0 {
+			return appInfoOutputFor(stdout, command.Args[0].Serialise(args[0]))
+		}
+	case AppStatus:
+		if len(args) > 0 {
+			return appStatusOutputFor(stdout, command.Args[0].Serialise(args[0]))
+		}
+	}
+	return stdout
+}
+
 // CommandWithArgs simply serves as a wrapper for the arguments that are passed to SteamCMD.Flow.
 type CommandWithArgs struct {
 	Command *Command
@@ -323,7 +824,7 @@ func NewCommandWithArgs(commandType CommandType, args ...any) *CommandWithArgs {
 		ok      bool
 	)
 
-	if command, ok = commands[commandType]; !ok {
+	if command, ok = lookupCommand(commandType); !ok {
 		command = commands[Quit]
 	}
 	return &CommandWithArgs{
@@ -332,10 +833,111 @@ func NewCommandWithArgs(commandType CommandType, args ...any) *CommandWithArgs {
 	}
 }
 
+// ValidateCommandOrder checks that the Quit command appears at most once in the given CommandWithArgs, and that if it
+// does appear, it is the last command. ErrQuitMustBeLast is returned if this is not the case. It also checks that the
+// ForceInstallDir command does not come after an AppUpdate command, otherwise ErrInstallDirAfterAppUpdate is returned.
+func ValidateCommandOrder(cmds []*CommandWithArgs) error {
+	appUpdateYet := false
+	for i, command := range cmds {
+		switch command.Command.Type {
+		case Quit:
+			if i != len(cmds)-1 {
+				return errors.Wrapf(
+					ErrQuitMustBeLast, "Quit is command no. %d but there are %d commands",
+					i, len(cmds),
+				)
+			}
+		case AppUpdate:
+			appUpdateYet = true
+		case ForceInstallDir:
+			if appUpdateYet {
+				return errors.Wrapf(ErrInstallDirAfterAppUpdate, "ForceInstallDir is command no. %d", i)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateFlow validates the given CommandWithArgs before they are executed by SteamCMD.Flow. This checks the args of
+// each command using Command.ValidateArgs, as well as the order of the commands using ValidateCommandOrder.
+func ValidateFlow(commandWithArgs ...*CommandWithArgs) error {
+	for i, command := range commandWithArgs {
+		if !command.Command.ValidateArgs(command.Args...) {
+			return errors.Errorf(
+				"command no. %d (%s) was given an invalid arg (%v)",
+				i, command.Command.Type.String(), command.Args,
+			)
+		}
+	}
+	return ValidateCommandOrder(commandWithArgs)
+}
+
+// AbortFlow will request that the current SteamCMD.Flow is aborted. The Flow will stop before it queues/executes its
+// next Command, and will return ErrFlowAborted with the given reason. An aborted Flow will not execute the Quit
+// command, instead the SteamCMD process is killed straight away. The SteamCMD.ParsedOutputs collected before the abort
+// are preserved. This can be called from another goroutine, or from within a CommandOutputParser.
+func (sc *SteamCMD) AbortFlow(reason string) error {
+	sc.abortMu.Lock()
+	defer sc.abortMu.Unlock()
+	if sc.closed {
+		return errors.New("cannot abort the flow of a SteamCMD that has already been closed")
+	}
+
+	sc.abortRequested = true
+	sc.abortReason = reason
+	return nil
+}
+
+// setClosed marks the SteamCMD as closed. This is guarded by abortMu, as closed can be read by SteamCMD.AbortFlow from
+// another goroutine.
+func (sc *SteamCMD) setClosed() {
+	sc.abortMu.Lock()
+	defer sc.abortMu.Unlock()
+	sc.closed = true
+}
+
+// flowAborted returns ErrFlowAborted if SteamCMD.AbortFlow has been called. Otherwise, nil is returned.
+func (sc *SteamCMD) flowAborted() error {
+	sc.abortMu.Lock()
+	defer sc.abortMu.Unlock()
+	if sc.abortRequested {
+		return ErrFlowAborted{Reason: sc.abortReason}
+	}
+	return nil
+}
+
+// abort will close the SteamCMD without executing any of the queued commands in non-interactive mode, and without
+// waiting for the Quit command in interactive mode.
+func (sc *SteamCMD) abort() (err error) {
+	defer func() {
+		sc.setClosed()
+		sc.publish(EventSessionClosed, nil)
+		sc.unsubscribeAll()
+	}()
+
+	if sc.interactive {
+		// Setting quitYet stops closeInteractive from executing the Quit command, and a timeout of 0 will kill the
+		// process straight away.
+		sc.quitYet = true
+		return sc.closeInteractive(context.Background(), 0)
+	}
+	return
+}
+
 // Flow will start the SteamCMD by running SteamCMD.Start, queue up a flow of CommandWithArgs one at a time, then finally
-// call Close on the SteamCMD.
+// call Close on the SteamCMD. The flow is validated using ValidateFlow before SteamCMD is started. If
+// SteamCMD.AbortFlow is called then the flow will stop before the next Command, and ErrFlowAborted will be returned.
 func (sc *SteamCMD) Flow(commandWithArgs ...*CommandWithArgs) (err error) {
+	if err = ValidateFlow(commandWithArgs...); err != nil {
+		return errors.Wrap(err, "invalid flow")
+	}
+
 	defer func(sc *SteamCMD) {
+		if abortErr := sc.flowAborted(); abortErr != nil {
+			// ErrFlowAborted is placed first so that it can be checked using errors.As
+			err = agem.MergeErrors(abortErr, err, errors.Wrap(sc.abort(), "cannot abort flow"))
+			return
+		}
 		err = agem.MergeErrors(err, errors.Wrap(sc.Close(), "cannot close flow"))
 	}(sc)
 
@@ -344,13 +946,59 @@ func (sc *SteamCMD) Flow(commandWithArgs ...*CommandWithArgs) (err error) {
 	}
 
 	for i, command := range commandWithArgs {
+		if sc.flowAborted() != nil {
+			return
+		}
+
 		//fmt.Printf("CommandWithArgs no. %d: \"%s\"\n", i, command.Command.Serialise(command.Args...))
 		if err = sc.AddCommand(command.Command, command.Args...); err != nil {
 			return errors.Wrapf(
 				err, "could not queue/execute command no. %d (%s)",
-				i, command.Command.Serialise(command.Args...),
+				i, sc.redactSecrets(command.Command.SerialiseRedacted(command.Args...)),
 			)
 		}
 	}
 	return
 }
+
+// FlowWithFreshInfo will run a Flow that executes AppInfoPrint for the given app ID, and then Quit. The app info cache
+// will be updated before AppInfoPrint is executed, as if the SteamCMD was created using WithFreshData. WithFreshData is
+// restored to its previous value once the Flow has finished. The parsed output of the AppInfoPrint command is returned.
+func (sc *SteamCMD) FlowWithFreshInfo(appID int64) (output map[string]any, err error) {
+	defer func(freshData bool) { sc.freshData = freshData }(sc.freshData)
+	sc.freshData = true
+	if err = sc.Flow(NewCommandWithArgs(AppInfoPrint, appID), NewCommandWithArgs(Quit)); err != nil {
+		return nil, errors.Wrapf(err, "could not update and print app info for %d", appID)
+	}
+
+	var ok bool
+	if output, ok = sc.ParsedOutputs[len(sc.ParsedOutputs)-2].(map[string]any); !ok {
+		return nil, errors.Errorf(
+			"parsed output of AppInfoPrint for %d is not a map, it is a %T",
+			appID, sc.ParsedOutputs[len(sc.ParsedOutputs)-2],
+		)
+	}
+	return
+}
+
+// FlowWithRequest will run a Flow that executes AppInfoRequest for the given app ID, so that the app info is freshly
+// fetched from Steam, then AppInfoPrint to read the fetched app info, and finally Quit. The parsed output of the
+// AppInfoPrint command is returned.
+func (sc *SteamCMD) FlowWithRequest(appID int64) (output map[string]any, err error) {
+	if err = sc.Flow(
+		NewCommandWithArgs(AppInfoRequest, appID),
+		NewCommandWithArgs(AppInfoPrint, appID),
+		NewCommandWithArgs(Quit),
+	); err != nil {
+		return nil, errors.Wrapf(err, "could not request and print app info for %d", appID)
+	}
+
+	var ok bool
+	if output, ok = sc.ParsedOutputs[len(sc.ParsedOutputs)-2].(map[string]any); !ok {
+		return nil, errors.Errorf(
+			"parsed output of AppInfoPrint for %d is not a map, it is a %T",
+			appID, sc.ParsedOutputs[len(sc.ParsedOutputs)-2],
+		)
+	}
+	return
+}
@@ -2,10 +2,18 @@ package steamcmd
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"github.com/Netflix/go-expect"
 	"github.com/andygello555/url-fmt"
+	"github.com/pkg/errors"
 	"math/rand"
 	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -143,6 +151,7 @@ func ExampleParseSteamDate() {
 	fmt.Println(ParseSteamDate("Q3 2021"))
 	fmt.Println(ParseSteamDate("Q4 2021"))
 	fmt.Println(ParseSteamDate("2022"))
+	fmt.Println(ParseSteamDate("1570492800"))
 	fmt.Println(ParseSteamDate("Coming Soon"))
 	// Output:
 	// 2019-10-08 00:00:00 +0000 UTC <nil>
@@ -159,5 +168,499 @@ func ExampleParseSteamDate() {
 	// 2021-07-01 00:00:00 +0000 UTC <nil>
 	// 2021-10-01 00:00:00 +0000 UTC <nil>
 	// 2022-01-01 00:00:00 +0000 UTC <nil>
-	// 0001-01-01 00:00:00 +0000 UTC could not parse Coming Soon using DayShortMonthYear: parsing time "Coming Soon" as "2 Jan, 2006": cannot parse "Coming Soon" as "2"; could not parse Coming Soon using DayShortMonthYearNoCommas: parsing time "Coming Soon" as "2 Jan 2006": cannot parse "Coming Soon" as "2"; could not parse Coming Soon using ShortMonthDayYear: parsing time "Coming Soon" as "Jan 2, 2006": cannot parse "Coming Soon" as "Jan"; could not parse Coming Soon using DayShortMonthYearDots: parsing time "Coming Soon" as "2. Jan. 2006": cannot parse "Coming Soon" as "2"; could not parse Coming Soon using MonthDayNdOrdYear: parsing time "Coming Soon" as "January 2nd, 2006": cannot parse "Coming Soon" as "January"; could not parse Coming Soon using MonthDayRdOrdYear: parsing time "Coming Soon" as "January 2rd, 2006": cannot parse "Coming Soon" as "January"; could not parse Coming Soon using MonthDayStOrdYear: parsing time "Coming Soon" as "January 2st, 2006": cannot parse "Coming Soon" as "January"; could not parse Coming Soon using MonthDayThOrdYear: parsing time "Coming Soon" as "January 2th, 2006": cannot parse "Coming Soon" as "January"; could not parse Coming Soon using ShortMonthYear: parsing time "Coming Soon" as "Jan 2006": cannot parse "Coming Soon" as "Jan"; could not parse Coming Soon using QuarterYear: parsing time "Coming Soon" as "Q2 2006": cannot parse "Coming Soon" as "Q"; could not parse Coming Soon using Year: parsing time "Coming Soon" as "2006": cannot parse "Coming Soon" as "2006"
+	// 2019-10-08 00:00:00 +0000 UTC <nil>
+	// 0001-01-01 00:00:00 +0000 UTC could not parse Coming Soon using DayShortMonthYear: parsing time "Coming Soon" as "2 Jan, 2006": cannot parse "Coming Soon" as "2"; could not parse Coming Soon using DayShortMonthYearNoCommas: parsing time "Coming Soon" as "2 Jan 2006": cannot parse "Coming Soon" as "2"; could not parse Coming Soon using ShortMonthDayYear: parsing time "Coming Soon" as "Jan 2, 2006": cannot parse "Coming Soon" as "Jan"; could not parse Coming Soon using DayShortMonthYearDots: parsing time "Coming Soon" as "2. Jan. 2006": cannot parse "Coming Soon" as "2"; could not parse Coming Soon using MonthDayNdOrdYear: parsing time "Coming Soon" as "January 2nd, 2006": cannot parse "Coming Soon" as "January"; could not parse Coming Soon using MonthDayRdOrdYear: parsing time "Coming Soon" as "January 2rd, 2006": cannot parse "Coming Soon" as "January"; could not parse Coming Soon using MonthDayStOrdYear: parsing time "Coming Soon" as "January 2st, 2006": cannot parse "Coming Soon" as "January"; could not parse Coming Soon using MonthDayThOrdYear: parsing time "Coming Soon" as "January 2th, 2006": cannot parse "Coming Soon" as "January"; could not parse Coming Soon using ShortMonthYear: parsing time "Coming Soon" as "Jan 2006": cannot parse "Coming Soon" as "Jan"; could not parse Coming Soon using QuarterYear: parsing time "Coming Soon" as "Q2 2006": cannot parse "Coming Soon" as "Q"; could not parse Coming Soon using Year: parsing time "Coming Soon" as "2006": cannot parse "Coming Soon" as "2006"; could not parse Coming Soon using ISO8601Date: parsing time "Coming Soon" as "2006-01-02": cannot parse "Coming Soon" as "2006"; could not parse Coming Soon using ISO8601DateTime: parsing time "Coming Soon" as "2006-01-02T15:04:05Z": cannot parse "Coming Soon" as "2006"; could not parse Coming Soon using UnixTimestamp: cannot parse "Coming Soon" as a Unix timestamp: strconv.ParseInt: parsing "Coming Soon": invalid syntax
+}
+
+func TestSteamCMD_ExecuteRaw(t *testing.T) {
+	if _, err := New(false).ExecuteRaw("info", "", time.Second); err == nil {
+		t.Errorf("expected an error when executing a raw command on a non-interactive SteamCMD")
+	}
+
+	if _, err := New(true).ExecuteRaw("info", "", time.Second); err == nil {
+		t.Errorf("expected an error when executing a raw command on a SteamCMD that has not been started")
+	}
+}
+
+func TestNewWithCredentials(t *testing.T) {
+	for testNo, test := range []struct {
+		username, password, steamGuard string
+		expected                       string
+	}{
+		{"bob", "hunter2", "", "+login bob hunter2"},
+		{"bob", "hunter2", "4KXJ2", "+login bob hunter2 4KXJ2"},
+	} {
+		sc := NewWithCredentials(test.username, test.password, test.steamGuard, false)
+		if err := sc.AddCommandType(AppInfoPrint, 477160); err != nil {
+			t.Fatalf("%d: could not queue AppInfoPrint: %v", testNo+1, err)
+		}
+
+		expected := []string{test.expected, "+app_info_print 477160"}
+		if !reflect.DeepEqual(sc.commandLine(), expected) {
+			t.Errorf("%d: expected command line %v, got %v", testNo+1, expected, sc.commandLine())
+		}
+
+		if traces := sc.Traces(); len(traces) != 1 || traces[0].CommandType != AppInfoPrint {
+			t.Errorf("%d: expected only a trace for AppInfoPrint, got %v", testNo+1, traces)
+		}
+	}
+}
+
+func TestNewWithCredentials_redacted(t *testing.T) {
+	for testNo, test := range []struct {
+		body            string
+		expectedErrPart string
+	}{
+		{"exit 1", "([+login bob [REDACTED] [REDACTED] +login alice [REDACTED] +app_info_print 477160 +quit])"},
+		{"exit 0", "could not parse output for command \"+login alice [REDACTED]\""},
+	} {
+		fakeSteamCMD(t, test.body)
+		sc := NewWithCredentials("bob", "hunter2", "4KXJ2", false)
+		if err := sc.AddCommandType(Login, "alice", "swordfish"); err != nil {
+			t.Fatalf("%d: could not queue Login: %v", testNo+1, err)
+		}
+		if err := sc.AddCommandType(AppInfoPrint, 477160); err != nil {
+			t.Fatalf("%d: could not queue AppInfoPrint: %v", testNo+1, err)
+		}
+
+		expectedArgs := []any{"alice", Redacted}
+		if traces := sc.Traces(); !reflect.DeepEqual(traces[0].Args, expectedArgs) {
+			t.Errorf("%d: expected Login trace args %v, got %v", testNo+1, expectedArgs, traces[0].Args)
+		}
+
+		err := sc.Close()
+		if err == nil {
+			t.Fatalf("%d: expected an error", testNo+1)
+		}
+
+		msg := err.Error()
+		if !strings.Contains(msg, test.expectedErrPart) {
+			t.Errorf("%d: expected error to contain %q, got %q", testNo+1, test.expectedErrPart, msg)
+		}
+		for _, secret := range []string{"hunter2", "4KXJ2", "swordfish"} {
+			if strings.Contains(msg, secret) {
+				t.Errorf("%d: expected error to not contain %q, got %q", testNo+1, secret, msg)
+			}
+		}
+	}
+}
+
+func TestSteamCMD_AddCommand_uninstallRequiresLogin(t *testing.T) {
+	for testNo, test := range []struct {
+		sc          func() (*SteamCMD, error)
+		expectedErr error
+		expected    []string
+	}{
+		{
+			func() (*SteamCMD, error) { return New(false), nil },
+			ErrUninstallRequiresLogin,
+			[]string{"+login anonymous"},
+		},
+		{
+			func() (*SteamCMD, error) { return NewWithCredentials("bob", "hunter2", "", false), nil },
+			nil,
+			[]string{"+login bob hunter2", "+app_uninstall 740 -complete"},
+		},
+		{
+			func() (*SteamCMD, error) {
+				sc := New(false)
+				return sc, sc.AddCommandType(Login, "bob", "hunter2")
+			},
+			nil,
+			[]string{"+login anonymous", "+login bob hunter2", "+app_uninstall 740 -complete"},
+		},
+		{
+			func() (*SteamCMD, error) {
+				sc := NewWithCredentials("bob", "hunter2", "", false)
+				return sc, sc.AddCommandType(Login, "anonymous", "")
+			},
+			ErrUninstallRequiresLogin,
+			[]string{"+login bob hunter2", "+login anonymous"},
+		},
+		{
+			func() (*SteamCMD, error) { return NewFromScript("login bob hunter2") },
+			nil,
+			[]string{"+login bob hunter2", "+app_uninstall 740 -complete"},
+		},
+		{
+			func() (*SteamCMD, error) { return NewFromScript("login anonymous") },
+			ErrUninstallRequiresLogin,
+			[]string{"+login anonymous"},
+		},
+	} {
+		sc, err := test.sc()
+		if err != nil {
+			t.Fatalf("%d: could not create SteamCMD: %v", testNo+1, err)
+		}
+
+		if err = sc.AddCommandType(AppUninstall, 740, "-complete"); !errors.Is(err, test.expectedErr) {
+			t.Errorf("%d: expected error %v, got %v", testNo+1, test.expectedErr, err)
+		}
+
+		if !reflect.DeepEqual(sc.commandLine(), test.expected) {
+			t.Errorf("%d: expected command line %v, got %v", testNo+1, test.expected, sc.commandLine())
+		}
+	}
+}
+
+func TestValidateCommandOrder(t *testing.T) {
+	for testNo, test := range []struct {
+		cmds        []*CommandWithArgs
+		expectedErr error
+	}{
+		{[]*CommandWithArgs{}, nil},
+		{[]*CommandWithArgs{NewCommandWithArgs(AppInfoPrint, 477160)}, nil},
+		{[]*CommandWithArgs{NewCommandWithArgs(AppInfoPrint, 477160), NewCommandWithArgs(Quit)}, nil},
+		{[]*CommandWithArgs{NewCommandWithArgs(Quit)}, nil},
+		{
+			[]*CommandWithArgs{NewCommandWithArgs(Quit), NewCommandWithArgs(AppInfoPrint, 477160)},
+			ErrQuitMustBeLast,
+		},
+		{
+			[]*CommandWithArgs{NewCommandWithArgs(AppInfoPrint, 477160), NewCommandWithArgs(Quit), NewCommandWithArgs(Quit)},
+			ErrQuitMustBeLast,
+		},
+		{
+			[]*CommandWithArgs{NewCommandWithArgs(ForceInstallDir, "/games"), NewCommandWithArgs(AppUpdate, 740)},
+			nil,
+		},
+		{
+			[]*CommandWithArgs{NewCommandWithArgs(AppUpdate, 740), NewCommandWithArgs(ForceInstallDir, "/games")},
+			ErrInstallDirAfterAppUpdate,
+		},
+	} {
+		if err := ValidateCommandOrder(test.cmds); !errors.Is(err, test.expectedErr) {
+			t.Errorf("%d: expected error %v, got %v", testNo+1, test.expectedErr, err)
+		}
+	}
+}
+
+func TestSteamCMD_expectString(t *testing.T) {
+	for testNo, test := range []struct {
+		opts           []Option
+		output         string
+		expectedBefore string
+		expectedAfter  string
+	}{
+		{nil, "Loading Steam API...OK\nSteam>", "Loading Steam API...OK\r\n", "Steam>"},
+		{
+			[]Option{WithPromptRegex(DefaultPromptRegex)},
+			"Loading Steam API...OK\n\x1b[0mSteam>",
+			"Loading Steam API...OK\r\n\x1b[0m",
+			"Steam>",
+		},
+		{
+			[]Option{WithPromptRegex(regexp.MustCompile(`\x1b\[\dmSteam>`))},
+			"Loading Steam API...OK\n\x1b[1mSteam>",
+			"Loading Steam API...OK\r\n",
+			"\x1b[1mSteam>",
+		},
+	} {
+		func() {
+			var err error
+			sc := New(true, test.opts...)
+			if sc.console, err = expect.NewConsole(); err != nil {
+				t.Fatalf("%d: could not create console: %v", testNo+1, err)
+			}
+			defer sc.console.Close()
+
+			if _, err = sc.console.Tty().WriteString(test.output); err != nil {
+				t.Fatalf("%d: could not write to console: %v", testNo+1, err)
+			}
+
+			if err = sc.expectString("", InteractivePrompt, ExpectTimeout); err != nil {
+				t.Errorf("%d: unexpected error: %v", testNo+1, err)
+				return
+			}
+
+			if sc.before.String() != test.expectedBefore || sc.after.String() != test.expectedAfter {
+				t.Errorf(
+					"%d: expected before = %q and after = %q, got before = %q and after = %q",
+					testNo+1, test.expectedBefore, test.expectedAfter, sc.before.String(), sc.after.String(),
+				)
+			}
+		}()
+	}
+}
+
+func TestSteamCMD_expectPromptOrPattern(t *testing.T) {
+	for testNo, test := range []struct {
+		output         string
+		expectedBefore string
+		expectedAfter  string
+	}{
+		{"Logging in user 'bob'...\nLogged in OK\nSteam>", "Logging in user 'bob'...\r\nLogged in OK\r\n", "Steam>"},
+		{
+			"This computer has not been authenticated using Steam Guard.\nSteam Guard code:",
+			"This computer has not been authenticated using Steam Guard.\r\n",
+			"Steam Guard code:",
+		},
+		{
+			"This account is protected by a Steam Guard mobile authenticator.\nTwo-factor code:",
+			"This account is protected by a Steam Guard mobile authenticator.\r\n",
+			"Two-factor code:",
+		},
+	} {
+		func() {
+			var err error
+			sc := New(true)
+			if sc.console, err = expect.NewConsole(); err != nil {
+				t.Fatalf("%d: could not create console: %v", testNo+1, err)
+			}
+			defer sc.console.Close()
+
+			if _, err = sc.console.Tty().WriteString(test.output); err != nil {
+				t.Fatalf("%d: could not write to console: %v", testNo+1, err)
+			}
+
+			if err = sc.expectPromptOrPattern("", loginSteamGuardPromptPattern, ExpectTimeout); err != nil {
+				t.Errorf("%d: unexpected error: %v", testNo+1, err)
+				return
+			}
+
+			if sc.before.String() != test.expectedBefore || sc.after.String() != test.expectedAfter {
+				t.Errorf(
+					"%d: expected before = %q and after = %q, got before = %q and after = %q",
+					testNo+1, test.expectedBefore, test.expectedAfter, sc.before.String(), sc.after.String(),
+				)
+			}
+		}()
+	}
+}
+
+func TestSteamCMD_commandTimeout(t *testing.T) {
+	for testNo, test := range []struct {
+		opts        []Option
+		commandType CommandType
+		expected    time.Duration
+	}{
+		{nil, AppInfoPrint, ExpectTimeout},
+		{nil, AppUpdate, AppUpdateTimeout},
+		{[]Option{WithCommandTimeout(AppUpdate, time.Minute*10)}, AppUpdate, time.Minute * 10},
+		{[]Option{WithCommandTimeout(AppUpdate, time.Minute*10)}, AppInfoPrint, ExpectTimeout},
+		{[]Option{WithCommandTimeout(AppInfoPrint, time.Second)}, AppInfoPrint, time.Second},
+	} {
+		command := NewCommandWithArgs(test.commandType).Command
+		if actual := New(true, test.opts...).commandTimeout(command); actual != test.expected {
+			t.Errorf("%d: expected timeout %s, got %s", testNo+1, test.expected.String(), actual.String())
+		}
+	}
+}
+
+// fakeSteamCMD writes a shell script named "steamcmd" with the given body to a temporary directory, and prepends the
+// directory to the PATH for the duration of the test.
+func fakeSteamCMD(t *testing.T, body string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake steamcmd scripts are not supported on windows")
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "steamcmd"), []byte("#!/bin/sh\n"+body+"\n"), 0o755); err != nil {
+		t.Fatalf("could not write fake steamcmd: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+// fakeInteractiveSteamCMD writes a fake steamcmd using fakeSteamCMD that prints the InteractivePrompt after each line
+// that it reads, until it reads "quit". The given cases are the branches of a shell case statement that each line is
+// matched against, which can be used to print the output for each command.
+func fakeInteractiveSteamCMD(t *testing.T, cases string) {
+	t.Helper()
+	fakeSteamCMD(t, `printf 'Steam>'
+while IFS= read -r line; do
+	case "$line" in
+	quit) exit 0 ;;
+	`+cases+`
+	esac
+	printf 'Steam>'
+done`)
+}
+
+func TestSteamCMD_Traces(t *testing.T) {
+	fakeInteractiveSteamCMD(t, "app_info_print*) cat <<'EOF'\n"+sampleAppInfoPrintOutput+"\nEOF\n;;")
+
+	sc := New(true)
+	if err := sc.Start(); err != nil {
+		t.Fatalf("could not start SteamCMD: %v", err)
+	}
+
+	tests := []struct {
+		commandType     CommandType
+		args            []any
+		expectedArgs    []any
+		expectedRetries int
+		expectedErr     bool
+	}{
+		{AppInfoPrint, []any{477160}, []any{477160}, 0, false},
+		{AppInfoRequest, []any{620}, []any{620}, appInfoRequestMaxTries - 1, false},
+		{Login, []any{"bob", "hunter2"}, []any{"bob", Redacted}, loginMaxTries - 1, true},
+	}
+	for testNo, test := range tests {
+		if err := sc.AddCommandType(test.commandType, test.args...); (err != nil) != test.expectedErr {
+			t.Errorf("%d: expected an error: %t, got %v", testNo+1, test.expectedErr, err)
+		}
+	}
+
+	if err := sc.Close(); err != nil {
+		t.Fatalf("could not close SteamCMD: %v", err)
+	}
+
+	// Close will also execute the Quit command
+	traces := sc.Traces()
+	if len(traces) != len(tests)+1 || traces[len(tests)].CommandType != Quit {
+		t.Fatalf("expected %d traces followed by a trace for Quit, got %v", len(tests), traces)
+	}
+
+	for testNo, test := range tests {
+		trace := traces[testNo]
+		if trace.CommandType != test.commandType || !reflect.DeepEqual(trace.Args, test.expectedArgs) {
+			t.Errorf(
+				"%d: expected trace for %s %v, got %s %v",
+				testNo+1, test.commandType.String(), test.expectedArgs, trace.CommandType.String(), trace.Args,
+			)
+		}
+
+		if trace.StartTime.IsZero() || trace.EndTime.Before(trace.StartTime) ||
+			trace.Duration != trace.EndTime.Sub(trace.StartTime) {
+			t.Errorf(
+				"%d: expected valid times, got start = %s, end = %s, duration = %s",
+				testNo+1, trace.StartTime.String(), trace.EndTime.String(), trace.Duration.String(),
+			)
+		}
+
+		if trace.Retries != test.expectedRetries {
+			t.Errorf("%d: expected %d retries, got %d", testNo+1, test.expectedRetries, trace.Retries)
+		}
+
+		if (trace.Err != nil) != test.expectedErr {
+			t.Errorf("%d: expected an error: %t, got %v", testNo+1, test.expectedErr, trace.Err)
+		}
+	}
+
+	// Modifying the returned traces should not modify the traces of the SteamCMD
+	traces[0].Args[0] = 620
+	if sc.Traces()[0].Args[0] != 477160 {
+		t.Errorf("expected the args of the returned traces to be copied, got %v", sc.Traces()[0].Args)
+	}
+}
+
+func TestSteamCMD_CloseNonInteractiveTimeout(t *testing.T) {
+	fakeSteamCMD(t, "echo partial\nexec sleep 10")
+
+	sc := New(false, WithNonInteractiveTimeout(time.Millisecond*200))
+	start := time.Now()
+	err := sc.Close()
+	if elapsed := time.Since(start); elapsed > time.Second*5 {
+		t.Errorf("expected Close to return shortly after the timeout, took %s", elapsed.String())
+	}
+
+	var timeoutErr ErrNonInteractiveTimeout
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected ErrNonInteractiveTimeout, got %v", err)
+	}
+	if string(timeoutErr.Output) != "partial\n" {
+		t.Errorf("expected partial output %q, got %q", "partial\n", string(timeoutErr.Output))
+	}
+}
+
+func TestSteamCMD_CloseContext(t *testing.T) {
+	fakeSteamCMD(t, "exec sleep 10")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*200)
+	defer cancel()
+	sc := New(false, WithNonInteractiveTimeout(time.Minute))
+	err := sc.CloseContext(ctx)
+	if err == nil {
+		t.Fatalf("expected an error when the context is done")
+	}
+
+	var timeoutErr ErrNonInteractiveTimeout
+	if errors.As(err, &timeoutErr) {
+		t.Errorf("expected the context's error rather than ErrNonInteractiveTimeout, got %v", err)
+	}
+}
+
+func TestSteamCMD_CloseExitCode(t *testing.T) {
+	fakeSteamCMD(t, "exit 8")
+
+	if err := New(false).Close(); !errors.Is(err, ErrInsufficientDisk) {
+		t.Errorf("expected ErrInsufficientDisk, got %v", err)
+	}
+}
+
+func TestSteamCMD_AbortFlow(t *testing.T) {
+	rs := &RecordedSession{Commands: []RecordedCommand{
+		{CommandType: AppInfoPrint, Args: []any{477160}, Output: []byte(sampleAppInfoPrintOutput)},
+		{CommandType: AppInfoPrint, Args: []any{620}, Output: []byte(sampleAppInfoPrintOutput)},
+		{CommandType: AppInfoPrint, Args: []any{730}, Output: []byte(sampleAppInfoPrintOutput)},
+	}}
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := rs.Save(path); err != nil {
+		t.Fatalf("could not save recorded session: %v", err)
+	}
+
+	sc, err := NewReplaySession(path)
+	if err != nil {
+		t.Fatalf("could not create replay session: %v", err)
+	}
+
+	// The first command will abort the flow from within its parser
+	abortingCommand := *NewCommandWithArgs(AppInfoPrint).Command
+	abortingCommand.Parser = func(output []byte) (any, error) {
+		return "aborted", sc.AbortFlow("unexpected data")
+	}
+
+	err = sc.Flow(
+		&CommandWithArgs{Command: &abortingCommand, Args: []any{477160}},
+		NewCommandWithArgs(AppInfoPrint, 620),
+		NewCommandWithArgs(AppInfoPrint, 730),
+		NewCommandWithArgs(Quit),
+	)
+
+	var abortErr ErrFlowAborted
+	if !errors.As(err, &abortErr) {
+		t.Fatalf("expected ErrFlowAborted, got %v", err)
+	}
+	if abortErr.Reason != "unexpected data" {
+		t.Errorf("expected reason %q, got %q", "unexpected data", abortErr.Reason)
+	}
+
+	if !reflect.DeepEqual(sc.ParsedOutputs, []any{"aborted"}) {
+		t.Errorf("expected only the parsed output of the first command, got %v", sc.ParsedOutputs)
+	}
+
+	if traces := sc.Traces(); len(traces) != 1 {
+		t.Errorf("expected only the first command to be executed, got %d traces", len(traces))
+	}
+
+	if err = sc.AddCommandType(AppInfoPrint, 620); err == nil {
+		t.Errorf("expected an error when queuing a command after the flow has been aborted")
+	}
+}
+
+func TestSteamCMD_AbortFlowConcurrentClose(t *testing.T) {
+	fakeSteamCMD(t, "exit 0")
+
+	sc := New(false)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// AbortFlow can either succeed or return an error, depending on whether Close has finished, but it should
+		// never race with Close
+		for i := 0; i < 100; i++ {
+			_ = sc.AbortFlow("closing")
+		}
+	}()
+
+	if err := sc.Close(); err != nil {
+		t.Errorf("unexpected error whilst closing: %v", err)
+	}
+	wg.Wait()
+
+	if err := sc.AbortFlow("closed"); err == nil {
+		t.Errorf("expected an error when aborting the flow of a closed SteamCMD")
+	}
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/andygello555/agem"
 	"github.com/pkg/errors"
+	"strconv"
 	"time"
 )
 
@@ -26,8 +27,20 @@ const (
 	// correct date. The time.Time returned by SteamDateLayout.Parse will be a date to the first day of the quarter.
 	QuarterYear = "Q2 2006"
 	Year        = "2006"
+	// ISO8601Date and ISO8601DateTime are used by Steam Workshop and Steam Web API responses.
+	ISO8601Date     = "2006-01-02"
+	ISO8601DateTime = "2006-01-02T15:04:05Z"
+	// UnixTimestamp is the reference time as a Unix timestamp. Values are parsed as the number of seconds since the Unix
+	// epoch, rather than by using time.Parse. This is the format that the "steam_release_date" in the output of
+	// AppInfoPrint uses. Only timestamps of at least minUnixTimestamp are parsed, so that other numbers are not mistaken
+	// for dates.
+	UnixTimestamp = "1136239445"
 )
 
+// minUnixTimestamp is the smallest Unix timestamp that will be parsed by the UnixTimestamp SteamDateLayout. This is the
+// smallest 9-digit number, which lies in 1973, long before Steam existed.
+const minUnixTimestamp = 100000000
+
 // String returns the name of the SteamDateLayout.
 func (sdf SteamDateLayout) String() string {
 	switch sdf {
@@ -55,11 +68,57 @@ func (sdf SteamDateLayout) String() string {
 		return "QuarterYear"
 	case Year:
 		return "Year"
+	case ISO8601Date:
+		return "ISO8601Date"
+	case ISO8601DateTime:
+		return "ISO8601DateTime"
+	case UnixTimestamp:
+		return "UnixTimestamp"
 	default:
 		return "<nil>"
 	}
 }
 
+// ExampleDate returns an example date string that can be successfully parsed using SteamDateLayout.Parse. I.e.
+// "8 Oct, 2019" for DayShortMonthYear. This is useful for documentation and error messages, as some layouts are not
+// intuitive. An empty string is returned for unknown SteamDateLayout.
+func (sdf SteamDateLayout) ExampleDate() string {
+	switch sdf {
+	case DayShortMonthYear:
+		return "8 Oct, 2019"
+	case DayShortMonthYearNoCommas:
+		return "8 Oct 2019"
+	case ShortMonthDayYear:
+		return "Oct 8, 2019"
+	case DayShortMonthYearDots:
+		return "8. Oct. 2019"
+	case MonthDayNdOrdYear:
+		return "October 2nd, 2019"
+	case MonthDayRdOrdYear:
+		return "October 3rd, 2019"
+	case MonthDayStOrdYear:
+		return "October 1st, 2019"
+	case MonthDayThOrdYear:
+		return "October 8th, 2019"
+	case ShortMonthYear:
+		return "Oct 2019"
+	case FullMonthYear:
+		return "October 2019"
+	case QuarterYear:
+		return "Q4 2019"
+	case Year:
+		return "2019"
+	case ISO8601Date:
+		return "2019-10-08"
+	case ISO8601DateTime:
+		return "2019-10-08T14:30:00Z"
+	case UnixTimestamp:
+		return "1570545000"
+	default:
+		return ""
+	}
+}
+
 // Parse will attempt to parse the given date string value as a time.Time using the layout described by the
 // SteamDateLayout.
 //
@@ -67,6 +126,19 @@ func (sdf SteamDateLayout) String() string {
 // has been successfully parsed. This is evident in the QuarterYear SteamDateLayout, where the day of the parsed
 // time.Time is converted to the quarter number by setting the month and day to the correct values for that quarter.
 func (sdf SteamDateLayout) Parse(value string) (date time.Time, err error) {
+	// UnixTimestamps cannot be parsed using time.Parse
+	if sdf == UnixTimestamp {
+		var seconds int64
+		if seconds, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return time.Time{}, errors.Wrapf(err, "cannot parse %q as a Unix timestamp", value)
+		}
+
+		if seconds < minUnixTimestamp {
+			return time.Time{}, errors.Errorf("%q is not a plausible Unix timestamp, expected at least 9 digits", value)
+		}
+		return time.Unix(seconds, 0).UTC(), nil
+	}
+
 	if date, err = time.Parse(string(sdf), value); err == nil {
 		// For some SteamDateFormats we need to apply manipulations after the value has been parsed.
 		switch sdf {
@@ -91,6 +163,93 @@ func (sdf SteamDateLayout) Parse(value string) (date time.Time, err error) {
 	return
 }
 
+// Precision returns the DatePrecision of the dates that are parsed by the SteamDateLayout.
+func (sdf SteamDateLayout) Precision() DatePrecision {
+	switch sdf {
+	case ShortMonthYear, FullMonthYear:
+		return PrecisionMonth
+	case QuarterYear:
+		return PrecisionQuarter
+	case Year:
+		return PrecisionYear
+	default:
+		return PrecisionDay
+	}
+}
+
+// IsApproximate returns whether the dates that are parsed by the SteamDateLayout are approximate. I.e. QuarterYear,
+// ShortMonthYear, FullMonthYear, and Year all parse dates to the first day of the period, rather than an exact day.
+// This is the same as checking whether the SteamDateLayout.Precision is not PrecisionDay.
+func (sdf SteamDateLayout) IsApproximate() bool {
+	return sdf.Precision() != PrecisionDay
+}
+
+// Format will format the given date using the layout described by the SteamDateLayout. This is the inverse of
+// SteamDateLayout.Parse, so the QuarterYear SteamDateLayout will format the date using the quarter that the date lies
+// within. MonthDayNdOrdYear, MonthDayRdOrdYear, MonthDayStOrdYear, and MonthDayThOrdYear will all format the date using
+// the correct ordinal suffix for the day. I.e. "October 8th, 2019".
+func (sdf SteamDateLayout) Format(date time.Time) string {
+	switch sdf {
+	case UnixTimestamp:
+		return strconv.FormatInt(date.Unix(), 10)
+	case QuarterYear:
+		return fmt.Sprintf("Q%d %d", (int(date.Month())-1)/3+1, date.Year())
+	case MonthDayNdOrdYear, MonthDayRdOrdYear, MonthDayStOrdYear, MonthDayThOrdYear:
+		return fmt.Sprintf("%s %d%s, %d", date.Month().String(), date.Day(), ordinalSuffix(date.Day()), date.Year())
+	default:
+		return date.Format(string(sdf))
+	}
+}
+
+// ordinalSuffix returns the ordinal suffix for the given day of the month. I.e. "st" for 1, "nd" for 22, and "th" for 11.
+func ordinalSuffix(day int) string {
+	if day >= 11 && day <= 13 {
+		return "th"
+	}
+
+	switch day % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	default:
+		return "th"
+	}
+}
+
+// DatePrecision represents how precise a date parsed by a SteamDateLayout is. This can be used to display dates
+// appropriately based on what is actually known about the date.
+type DatePrecision int
+
+const (
+	// PrecisionDay is for dates where the day, month, and year are known.
+	PrecisionDay DatePrecision = iota
+	// PrecisionMonth is for dates where only the month and year are known.
+	PrecisionMonth
+	// PrecisionQuarter is for dates where only the quarter and year are known.
+	PrecisionQuarter
+	// PrecisionYear is for dates where only the year is known.
+	PrecisionYear
+)
+
+// String returns the name of the DatePrecision.
+func (dp DatePrecision) String() string {
+	switch dp {
+	case PrecisionDay:
+		return "Day"
+	case PrecisionMonth:
+		return "Month"
+	case PrecisionQuarter:
+		return "Quarter"
+	case PrecisionYear:
+		return "Year"
+	default:
+		return "<nil>"
+	}
+}
+
 // SteamDateLayouts contains all the SteamDateLayout.
 var SteamDateLayouts = []SteamDateLayout{
 	DayShortMonthYear,
@@ -104,17 +263,29 @@ var SteamDateLayouts = []SteamDateLayout{
 	ShortMonthYear,
 	QuarterYear,
 	Year,
+	ISO8601Date,
+	ISO8601DateTime,
+	UnixTimestamp,
 }
 
 // ParseSteamDate will parse the given string value to a date by attempting to parse it using each SteamDateLayout in
 // SteamDateLayouts. If the date string cannot be parsed, then the error that is returned will be the merged error
 // constructed from all the errors for each SteamDateLayout.
 func ParseSteamDate(value string) (date time.Time, err error) {
+	date, _, err = ParseSteamDateWithLayout(value)
+	return
+}
+
+// ParseSteamDateWithLayout is the same as ParseSteamDate, but it will also return the SteamDateLayout that was used to
+// successfully parse the date. The SteamDateLayout.Precision of the returned layout can be used to find out how precise
+// the parsed date is, and SteamDateLayout.IsApproximate can be used to check whether the date is approximate.
+func ParseSteamDateWithLayout(value string) (date time.Time, layout SteamDateLayout, err error) {
 	errs := make([]error, 0)
 	for _, format := range SteamDateLayouts {
 		if date, err = format.Parse(value); err != nil {
 			errs = append(errs, errors.Wrapf(err, "could not parse %s using %s", value, format.String()))
 		} else {
+			layout = format
 			err = nil
 			break
 		}
@@ -125,3 +296,25 @@ func ParseSteamDate(value string) (date time.Time, err error) {
 	}
 	return
 }
+
+// ParseSteamDatePrecision is the same as ParseSteamDate, but it will also return the DatePrecision of the
+// SteamDateLayout that was used to successfully parse the date.
+func ParseSteamDatePrecision(value string) (date time.Time, precision DatePrecision, err error) {
+	var layout SteamDateLayout
+	if date, layout, err = ParseSteamDateWithLayout(value); err != nil {
+		return
+	}
+	return date, layout.Precision(), nil
+}
+
+// FormatSteamDate will format the given date using the first SteamDateLayout in SteamDateLayouts that has the given
+// DatePrecision. This is the inverse of ParseSteamDatePrecision. Non-approximate SteamDateLayout are preferred, so
+// dates with PrecisionDay will always be formatted exactly. I.e. using DayShortMonthYear.
+func FormatSteamDate(date time.Time, precision DatePrecision) string {
+	for _, layout := range SteamDateLayouts {
+		if layout.Precision() == precision {
+			return layout.Format(date)
+		}
+	}
+	return SteamDateLayout(DayShortMonthYear).Format(date)
+}
@@ -0,0 +1,182 @@
+package steamcmd
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func ExampleParseSteamDatePrecision() {
+	for _, value := range []string{"8 Oct, 2019", "Oct 2019", "Q4 2019", "2019", "1570492800"} {
+		date, precision, err := ParseSteamDatePrecision(value)
+		fmt.Println(date, precision, err)
+	}
+	// Output:
+	// 2019-10-08 00:00:00 +0000 UTC Day <nil>
+	// 2019-10-01 00:00:00 +0000 UTC Month <nil>
+	// 2019-10-01 00:00:00 +0000 UTC Quarter <nil>
+	// 2019-01-01 00:00:00 +0000 UTC Year <nil>
+	// 2019-10-08 00:00:00 +0000 UTC Day <nil>
+}
+
+func TestParseSteamDateISO8601(t *testing.T) {
+	for testNo, test := range []struct {
+		value          string
+		expectedDate   time.Time
+		expectedLayout SteamDateLayout
+	}{
+		{"2019-10-08", time.Date(2019, 10, 8, 0, 0, 0, 0, time.UTC), ISO8601Date},
+		{"2019-10-08T12:00:00Z", time.Date(2019, 10, 8, 12, 0, 0, 0, time.UTC), ISO8601DateTime},
+	} {
+		date, layout, err := ParseSteamDateWithLayout(test.value)
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", testNo+1, err)
+			continue
+		}
+
+		if !date.Equal(test.expectedDate) || date.Location() != time.UTC {
+			t.Errorf("%d: expected %v, got %v", testNo+1, test.expectedDate, date)
+		}
+
+		if layout != test.expectedLayout {
+			t.Errorf("%d: expected layout %s, got %s", testNo+1, test.expectedLayout.String(), layout.String())
+		}
+	}
+}
+
+func TestSteamDateLayoutExamples(t *testing.T) {
+	for testNo, test := range []struct {
+		layout       SteamDateLayout
+		expectedDate time.Time
+	}{
+		{DayShortMonthYear, time.Date(2019, 10, 8, 0, 0, 0, 0, time.UTC)},
+		{DayShortMonthYearNoCommas, time.Date(2019, 10, 8, 0, 0, 0, 0, time.UTC)},
+		{ShortMonthDayYear, time.Date(2019, 10, 8, 0, 0, 0, 0, time.UTC)},
+		{DayShortMonthYearDots, time.Date(2019, 10, 8, 0, 0, 0, 0, time.UTC)},
+		{MonthDayNdOrdYear, time.Date(2019, 10, 2, 0, 0, 0, 0, time.UTC)},
+		{MonthDayRdOrdYear, time.Date(2019, 10, 3, 0, 0, 0, 0, time.UTC)},
+		{MonthDayStOrdYear, time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{MonthDayThOrdYear, time.Date(2019, 10, 8, 0, 0, 0, 0, time.UTC)},
+		{ShortMonthYear, time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{FullMonthYear, time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{QuarterYear, time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{Year, time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ISO8601Date, time.Date(2019, 10, 8, 0, 0, 0, 0, time.UTC)},
+		{ISO8601DateTime, time.Date(2019, 10, 8, 14, 30, 0, 0, time.UTC)},
+		{UnixTimestamp, time.Date(2019, 10, 8, 14, 30, 0, 0, time.UTC)},
+	} {
+		example := test.layout.ExampleDate()
+		date, err := test.layout.Parse(example)
+		if err != nil {
+			t.Errorf("%d: could not parse example %q for %s: %v", testNo+1, example, test.layout.String(), err)
+			continue
+		}
+
+		if !date.Equal(test.expectedDate) {
+			t.Errorf("%d: expected example %q for %s to be %v, got %v", testNo+1, example, test.layout.String(), test.expectedDate, date)
+		}
+	}
+
+	if example := SteamDateLayout("unknown").ExampleDate(); example != "" {
+		t.Errorf("expected no example for an unknown layout, got %q", example)
+	}
+}
+
+func TestSteamDateLayout_IsApproximate(t *testing.T) {
+	for testNo, test := range []struct {
+		layout   SteamDateLayout
+		expected bool
+	}{
+		{DayShortMonthYear, false},
+		{DayShortMonthYearNoCommas, false},
+		{ShortMonthDayYear, false},
+		{DayShortMonthYearDots, false},
+		{MonthDayNdOrdYear, false},
+		{MonthDayRdOrdYear, false},
+		{MonthDayStOrdYear, false},
+		{MonthDayThOrdYear, false},
+		{ShortMonthYear, true},
+		{FullMonthYear, true},
+		{QuarterYear, true},
+		{Year, true},
+		{ISO8601Date, false},
+		{ISO8601DateTime, false},
+		{UnixTimestamp, false},
+	} {
+		if approximate := test.layout.IsApproximate(); approximate != test.expected {
+			t.Errorf("%d: expected %s.IsApproximate() to be %t, got %t", testNo+1, test.layout.String(), test.expected, approximate)
+		}
+	}
+}
+
+func ExampleFormatSteamDate() {
+	date := time.Date(2019, 10, 8, 0, 0, 0, 0, time.UTC)
+	for _, precision := range []DatePrecision{PrecisionDay, PrecisionMonth, PrecisionQuarter, PrecisionYear} {
+		value := FormatSteamDate(date, precision)
+		parsed, parsedPrecision, err := ParseSteamDatePrecision(value)
+		fmt.Println(value, parsed, parsedPrecision, err)
+	}
+	// Output:
+	// 8 Oct, 2019 2019-10-08 00:00:00 +0000 UTC Day <nil>
+	// Oct 2019 2019-10-01 00:00:00 +0000 UTC Month <nil>
+	// Q4 2019 2019-10-01 00:00:00 +0000 UTC Quarter <nil>
+	// 2019 2019-01-01 00:00:00 +0000 UTC Year <nil>
+}
+
+func TestParseSteamDate_unixTimestamp(t *testing.T) {
+	for testNo, test := range []struct {
+		value        string
+		expectedDate time.Time
+		expectedErr  bool
+	}{
+		{"1570492800", time.Date(2019, 10, 8, 0, 0, 0, 0, time.UTC), false},
+		{"100000000", time.Date(1973, 3, 3, 9, 46, 40, 0, time.UTC), false},
+		{"99999999", time.Time{}, true},
+		{"201", time.Time{}, true},
+		{"0", time.Time{}, true},
+		{"-1570492800", time.Time{}, true},
+	} {
+		date, layout, err := ParseSteamDateWithLayout(test.value)
+		if (err != nil) != test.expectedErr {
+			t.Errorf("%d: expected an error: %t, got %v", testNo+1, test.expectedErr, err)
+			continue
+		}
+
+		if !test.expectedErr && (!date.Equal(test.expectedDate) || layout != UnixTimestamp) {
+			t.Errorf("%d: expected %v using UnixTimestamp, got %v using %s", testNo+1, test.expectedDate, date, layout.String())
+		}
+	}
+}
+
+func TestSteamDateLayout_Format(t *testing.T) {
+	for testNo, test := range []struct {
+		layout   SteamDateLayout
+		date     time.Time
+		expected string
+	}{
+		{MonthDayNdOrdYear, time.Date(2019, 10, 8, 0, 0, 0, 0, time.UTC), "October 8th, 2019"},
+		{MonthDayRdOrdYear, time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC), "October 1st, 2019"},
+		{MonthDayStOrdYear, time.Date(2019, 10, 2, 0, 0, 0, 0, time.UTC), "October 2nd, 2019"},
+		{MonthDayThOrdYear, time.Date(2019, 10, 3, 0, 0, 0, 0, time.UTC), "October 3rd, 2019"},
+		{MonthDayThOrdYear, time.Date(2019, 10, 11, 0, 0, 0, 0, time.UTC), "October 11th, 2019"},
+		{MonthDayThOrdYear, time.Date(2019, 10, 12, 0, 0, 0, 0, time.UTC), "October 12th, 2019"},
+		{MonthDayThOrdYear, time.Date(2019, 10, 13, 0, 0, 0, 0, time.UTC), "October 13th, 2019"},
+		{MonthDayThOrdYear, time.Date(2019, 10, 21, 0, 0, 0, 0, time.UTC), "October 21st, 2019"},
+		{MonthDayThOrdYear, time.Date(2019, 10, 22, 0, 0, 0, 0, time.UTC), "October 22nd, 2019"},
+		{MonthDayThOrdYear, time.Date(2019, 10, 23, 0, 0, 0, 0, time.UTC), "October 23rd, 2019"},
+		{MonthDayThOrdYear, time.Date(2019, 10, 31, 0, 0, 0, 0, time.UTC), "October 31st, 2019"},
+		{DayShortMonthYear, time.Date(2019, 10, 8, 0, 0, 0, 0, time.UTC), "8 Oct, 2019"},
+		{QuarterYear, time.Date(2019, 10, 1, 0, 0, 0, 0, time.UTC), "Q4 2019"},
+	} {
+		value := test.layout.Format(test.date)
+		if value != test.expected {
+			t.Errorf("%d: expected %s to format %v as %q, got %q", testNo+1, test.layout.String(), test.date, test.expected, value)
+			continue
+		}
+
+		// Each formatted value should parse back to the same date
+		if date, err := ParseSteamDate(value); err != nil || !date.Equal(test.date) {
+			t.Errorf("%d: expected %q to parse to %v, got %v (%v)", testNo+1, value, test.date, date, err)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package steamcmd
+
+import "time"
+
+// ExecutionTrace records when a Command was executed by SteamCMD, and how long it took to execute.
+type ExecutionTrace struct {
+	// CommandType is the CommandType of the executed Command.
+	CommandType CommandType
+	// Args are the args that the Command was executed with.
+	Args []any
+	// StartTime is when the Command started executing. In non-interactive mode, this is when the SteamCMD process was
+	// started, as the execution time of each individual Command cannot be separated.
+	StartTime time.Time
+	// EndTime is when the Command finished executing. In non-interactive mode, this is when the SteamCMD process exited.
+	EndTime time.Time
+	// Duration is the difference between StartTime and EndTime.
+	Duration time.Duration
+	// Retries is the number of times that the Command was retried in interactive mode before its output could be
+	// validated. This is always 0 in non-interactive mode.
+	Retries int
+	// Err is the error that occurred whilst executing the Command in interactive mode, if any.
+	Err error
+}
+
+// copy returns a copy of the ExecutionTrace, including a copy of its Args.
+func (et ExecutionTrace) copy() ExecutionTrace {
+	if et.Args != nil {
+		et.Args = append([]any{}, et.Args...)
+	}
+	return et
+}
+
+// setTimes will set the StartTime, EndTime, and Duration of the ExecutionTrace.
+func (et *ExecutionTrace) setTimes(start, end time.Time) {
+	et.StartTime = start
+	et.EndTime = end
+	et.Duration = end.Sub(start)
+}
+
+// Traces returns a copy of the ExecutionTrace for each Command that has been queued/executed by the SteamCMD. In
+// non-interactive mode, the times of each ExecutionTrace will only be set after SteamCMD.Close has been called.
+func (sc *SteamCMD) Traces() []ExecutionTrace {
+	traces := make([]ExecutionTrace, len(sc.traces))
+	for i, trace := range sc.traces {
+		traces[i] = trace.copy()
+	}
+	return traces
+}
+
+// CommandHistory returns a copy of the ExecutionTrace for each Command that has been executed by the SteamCMD, in the
+// order that they were executed. Unlike SteamCMD.Traces, Command that have been queued in non-interactive mode but have
+// not yet been executed by calling SteamCMD.Close are not included.
+func (sc *SteamCMD) CommandHistory() []ExecutionTrace {
+	history := make([]ExecutionTrace, 0, len(sc.traces))
+	for _, trace := range sc.traces {
+		if !trace.StartTime.IsZero() {
+			history = append(history, trace.copy())
+		}
+	}
+	return history
+}